@@ -0,0 +1,214 @@
+package goyave
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"goyave.dev/goyave/v3/database"
+	"goyave.dev/goyave/v3/helper"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// BindOptions options for route model binding.
+type BindOptions struct {
+
+	// Column the column compared with the value of the route parameter.
+	// Defaults to the primary key of the model.
+	Column string
+
+	// Parent the name of a parameter previously bound on the same route.
+	// If not empty, the record is only found if it belongs to the parent record.
+	Parent string
+
+	// ForeignKey the column referencing the parent record. Defaults to the
+	// name of the parent model followed by the name of its primary key, in
+	// snake case (e.g. "article_id" for the "Article" model).
+	ForeignKey string
+}
+
+type binding struct {
+	*BindOptions
+	parameter string
+	modelType reflect.Type
+}
+
+// Bind the given route parameter to a model. Before the handler is executed,
+// the record whose primary key matches the parameter's value is loaded
+// through "database.GetConnection()" and stored in "Request.Extra", using the
+// parameter's name as key. If the record doesn't exist, or if the parameter's
+// value cannot be converted to the type of the column (e.g. "abc" for an integer
+// primary key), the status handler for "404 Not Found" is executed instead of
+// the handler.
+//
+//  router.Get("/articles/{article}", article.Show).Bind("article", &model.Article{})
+//
+//  func Show(response *goyave.Response, request *goyave.Request) {
+//    article := request.Extra["article"].(*model.Article)
+//    response.JSON(http.StatusOK, article)
+//  }
+//
+// Bindings are resolved after the middleware of the parent routers and
+// before the route's own middleware, so the latter can use the loaded records.
+//
+// Panics if the route doesn't have the given parameter or if the model is not
+// a struct or a pointer to a struct.
+//
+// Returns itself.
+func (r *Route) Bind(parameter string, model interface{}) *Route {
+	return r.BindWithOptions(parameter, model, nil)
+}
+
+// BindWithOptions works like "Bind" but with custom options.
+// The options can be nil.
+//
+//  router.Get("/articles/{article}/comments/{comment}", comment.Show).
+//    Bind("article", &model.Article{}).
+//    BindWithOptions("comment", &model.Comment{}, &goyave.BindOptions{
+//      Column: "slug",
+//      Parent: "article",
+//    })
+//
+// Returns itself.
+func (r *Route) BindWithOptions(parameter string, model interface{}, options *BindOptions) *Route {
+	if options == nil {
+		options = &BindOptions{}
+	}
+
+	_, parameters := r.GetFullURIAndParameters()
+	if !helper.ContainsStr(parameters, parameter) {
+		panic(fmt.Errorf("Cannot bind %q: route doesn't have this parameter", parameter))
+	}
+
+	modelType := reflect.TypeOf(model)
+	if modelType != nil && modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType == nil || modelType.Kind() != reflect.Struct {
+		panic(fmt.Errorf("Cannot bind %q: model must be a struct or a pointer to a struct", parameter))
+	}
+
+	if options.Parent != "" && r.getBinding(options.Parent) == nil {
+		panic(fmt.Errorf("Cannot bind %q: parent %q is not bound", parameter, options.Parent))
+	}
+
+	r.bindings = append(r.bindings, &binding{
+		BindOptions: options,
+		parameter:   parameter,
+		modelType:   modelType,
+	})
+	return r
+}
+
+func (r *Route) getBinding(parameter string) *binding {
+	for _, b := range r.bindings {
+		if b.parameter == parameter {
+			return b
+		}
+	}
+	return nil
+}
+
+// bindMiddleware loads the records bound to the route parameters.
+func bindMiddleware(bindings []*binding) Middleware {
+	return func(next Handler) Handler {
+		return func(response *Response, r *Request) {
+			db := database.GetConnection().WithContext(r.Context())
+			for _, b := range bindings {
+				record, result := b.find(db, r)
+				if !response.HandleDatabaseError(result) {
+					return
+				}
+				r.Extra[b.parameter] = record
+			}
+			next(response, r)
+		}
+	}
+}
+
+// find the record matching the value of the bound parameter.
+func (b *binding) find(db *gorm.DB, r *Request) (interface{}, *gorm.DB) {
+	record := reflect.New(b.modelType).Interface()
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(record); err != nil {
+		db.AddError(err)
+		return nil, db
+	}
+
+	column := b.Column
+	if column == "" {
+		if stmt.Schema.PrioritizedPrimaryField == nil {
+			db.AddError(fmt.Errorf("Cannot bind %q: model %s doesn't have a primary key", b.parameter, stmt.Schema.Name))
+			return nil, db
+		}
+		column = stmt.Schema.PrioritizedPrimaryField.DBName
+	}
+
+	value, ok := convertBindingValue(stmt.Schema.LookUpField(column), r.Params[b.parameter])
+	if !ok {
+		// The value cannot match any record
+		db.AddError(gorm.ErrRecordNotFound)
+		return nil, db
+	}
+
+	query := db.Where(clause.Eq{
+		Column: clause.Column{Table: clause.CurrentTable, Name: column},
+		Value:  value,
+	})
+
+	if b.Parent != "" {
+		parent := r.Extra[b.Parent]
+		parentStmt := &gorm.Statement{DB: db}
+		if err := parentStmt.Parse(parent); err != nil {
+			db.AddError(err)
+			return nil, db
+		}
+		primaryKey := parentStmt.Schema.PrioritizedPrimaryField
+		if primaryKey == nil {
+			db.AddError(fmt.Errorf("Cannot bind %q: parent model %s doesn't have a primary key", b.parameter, parentStmt.Schema.Name))
+			return nil, db
+		}
+		foreignKey := b.ForeignKey
+		if foreignKey == "" {
+			foreignKey = db.NamingStrategy.ColumnName("", parentStmt.Schema.Name+primaryKey.Name)
+		}
+		parentID, _ := primaryKey.ValueOf(reflect.ValueOf(parent))
+		query = query.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: foreignKey},
+			Value:  parentID,
+		})
+	}
+
+	return record, query.Take(record)
+}
+
+// convertBindingValue converts the given parameter value to the type of the
+// given field if it is a number or a boolean. Returns false if the value
+// cannot be converted. Other values are returned as is.
+func convertBindingValue(field *schema.Field, value string) (interface{}, bool) {
+	if field == nil {
+		return value, true
+	}
+	fieldType := field.FieldType
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	var converted interface{}
+	var err error
+	switch fieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		converted, err = strconv.ParseInt(value, 10, fieldType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		converted, err = strconv.ParseUint(value, 10, fieldType.Bits())
+	case reflect.Float32, reflect.Float64:
+		converted, err = strconv.ParseFloat(value, fieldType.Bits())
+	case reflect.Bool:
+		converted, err = strconv.ParseBool(value)
+	default:
+		return value, true
+	}
+	return converted, err == nil
+}
@@ -0,0 +1,169 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"goyave.dev/goyave/v3/config"
+	"goyave.dev/goyave/v3/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+type bindingTestArticle struct {
+	gorm.Model
+	Slug string
+}
+
+type bindingTestComment struct {
+	gorm.Model
+	Content              string
+	BindingTestArticleID uint
+}
+
+type BindingTestSuite struct {
+	TestSuite
+}
+
+func (suite *BindingTestSuite) serve(router *Router, uri string) (*http.Response, string) {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", uri, nil))
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	return resp, string(body)
+}
+
+func (suite *BindingTestSuite) TestBindWithOptions() {
+	router := NewRouter()
+	handler := func(response *Response, r *Request) {}
+	route := router.Subrouter("/articles/{article}").Get("/comments/{comment}", handler)
+
+	suite.Same(route, route.Bind("article", &bindingTestArticle{}))
+	suite.Same(route, route.BindWithOptions("comment", bindingTestComment{}, &BindOptions{Parent: "article"}))
+	suite.Len(route.bindings, 2)
+	suite.Equal("article", route.bindings[0].parameter)
+	suite.NotNil(route.bindings[0].BindOptions)
+	suite.Equal("comment", route.bindings[1].parameter)
+	suite.Equal("article", route.bindings[1].Parent)
+
+	suite.Panics(func() {
+		route.Bind("user", &bindingTestArticle{})
+	})
+	suite.Panics(func() {
+		route.Bind("article", "article")
+	})
+	suite.Panics(func() {
+		route.Bind("article", nil)
+	})
+	suite.Panics(func() {
+		route.BindWithOptions("comment", &bindingTestComment{}, &BindOptions{Parent: "user"})
+	})
+}
+
+func (suite *BindingTestSuite) TestBind() {
+	config.Set("database.connection", "mysql")
+	defer config.Set("database.connection", "none")
+	db := database.GetConnection()
+	db.AutoMigrate(&bindingTestArticle{}, &bindingTestComment{})
+	defer db.Migrator().DropTable(&bindingTestArticle{}, &bindingTestComment{})
+
+	article := &bindingTestArticle{Slug: "hello-world"}
+	other := &bindingTestArticle{Slug: "other"}
+	db.Create(article)
+	db.Create(other)
+	comment := &bindingTestComment{Content: "first", BindingTestArticleID: article.ID}
+	db.Create(comment)
+
+	router := NewRouter()
+	router.Get("/articles/{article}", func(response *Response, r *Request) {
+		response.String(http.StatusOK, r.Extra["article"].(*bindingTestArticle).Slug)
+	}).Bind("article", &bindingTestArticle{}).Middleware(func(next Handler) Handler {
+		return func(response *Response, r *Request) {
+			// Bound models are available in route middleware
+			response.Header().Set("X-Article", r.Extra["article"].(*bindingTestArticle).Slug)
+			next(response, r)
+		}
+	})
+	router.Get("/slug/{article}", func(response *Response, r *Request) {
+		response.String(http.StatusOK, strconv.FormatUint(uint64(r.Extra["article"].(*bindingTestArticle).ID), 10))
+	}).BindWithOptions("article", bindingTestArticle{}, &BindOptions{Column: "slug"})
+	router.Get("/articles/{article}/comments/{comment}", func(response *Response, r *Request) {
+		response.String(http.StatusOK, r.Extra["comment"].(*bindingTestComment).Content)
+	}).Bind("article", &bindingTestArticle{}).BindWithOptions("comment", &bindingTestComment{}, &BindOptions{Parent: "article"})
+	router.Get("/fk/{article}/comments/{comment}", func(response *Response, r *Request) {}).
+		Bind("article", &bindingTestArticle{}).
+		BindWithOptions("comment", &bindingTestComment{}, &BindOptions{Parent: "article", ForeignKey: "not_a_column"})
+
+	articleID := strconv.FormatUint(uint64(article.ID), 10)
+	otherID := strconv.FormatUint(uint64(other.ID), 10)
+	commentID := strconv.FormatUint(uint64(comment.ID), 10)
+
+	resp, body := suite.serve(router, "/articles/"+articleID)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("hello-world", resp.Header.Get("X-Article"))
+	suite.Equal("hello-world", body)
+
+	resp, _ = suite.serve(router, "/articles/-1")
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	// Not an integer
+	resp, _ = suite.serve(router, "/articles/abc")
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, body = suite.serve(router, "/slug/hello-world")
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(articleID, body)
+
+	resp, _ = suite.serve(router, "/slug/"+articleID)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, body = suite.serve(router, "/articles/"+articleID+"/comments/"+commentID)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("first", body)
+
+	// The comment doesn't belong to this article
+	resp, _ = suite.serve(router, "/articles/"+otherID+"/comments/"+commentID)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	prev := ErrLogger.Writer()
+	ErrLogger.SetOutput(ioutil.Discard)
+	defer ErrLogger.SetOutput(prev)
+	resp, _ = suite.serve(router, "/fk/"+articleID+"/comments/"+commentID)
+	suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+}
+
+func (suite *BindingTestSuite) TestConvertBindingValue() {
+	s, err := schema.Parse(&bindingTestComment{}, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		panic(err)
+	}
+
+	value, ok := convertBindingValue(s.LookUpField("id"), "42")
+	suite.True(ok)
+	suite.Equal(uint64(42), value)
+
+	_, ok = convertBindingValue(s.LookUpField("id"), "abc")
+	suite.False(ok)
+	_, ok = convertBindingValue(s.LookUpField("id"), "-1")
+	suite.False(ok)
+
+	value, ok = convertBindingValue(s.LookUpField("content"), "abc")
+	suite.True(ok)
+	suite.Equal("abc", value)
+
+	value, ok = convertBindingValue(nil, "abc")
+	suite.True(ok)
+	suite.Equal("abc", value)
+}
+
+func TestBindingSuite(t *testing.T) {
+	RunTest(t, new(BindingTestSuite))
+}
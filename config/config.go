@@ -35,16 +35,18 @@ var configDefaults object = object{
 		"environment":     &Entry{"localhost", []interface{}{}, reflect.String, false},
 		"debug":           &Entry{true, []interface{}{}, reflect.Bool, false},
 		"defaultLanguage": &Entry{"en-US", []interface{}{}, reflect.String, false},
+		"key":             &Entry{"", []interface{}{}, reflect.String, false},
 	},
 	"server": object{
-		"host":          &Entry{"127.0.0.1", []interface{}{}, reflect.String, false},
-		"domain":        &Entry{"", []interface{}{}, reflect.String, false},
-		"protocol":      &Entry{"http", []interface{}{"http", "https"}, reflect.String, false},
-		"port":          &Entry{8080, []interface{}{}, reflect.Int, false},
-		"httpsPort":     &Entry{8081, []interface{}{}, reflect.Int, false},
-		"timeout":       &Entry{10, []interface{}{}, reflect.Int, false},
-		"maxUploadSize": &Entry{10.0, []interface{}{}, reflect.Float64, false},
-		"maintenance":   &Entry{false, []interface{}{}, reflect.Bool, false},
+		"host":                  &Entry{"127.0.0.1", []interface{}{}, reflect.String, false},
+		"domain":                &Entry{"", []interface{}{}, reflect.String, false},
+		"protocol":              &Entry{"http", []interface{}{"http", "https"}, reflect.String, false},
+		"port":                  &Entry{8080, []interface{}{}, reflect.Int, false},
+		"httpsPort":             &Entry{8081, []interface{}{}, reflect.Int, false},
+		"timeout":               &Entry{10, []interface{}{}, reflect.Int, false},
+		"maxUploadSize":         &Entry{10.0, []interface{}{}, reflect.Float64, false},
+		"maintenance":           &Entry{false, []interface{}{}, reflect.Bool, false},
+		"validationErrorFormat": &Entry{"default", []interface{}{"default", "detailed", "problem"}, reflect.String, false},
 		"tls": object{
 			"cert": &Entry{nil, []interface{}{}, reflect.String, false},
 			"key":  &Entry{nil, []interface{}{}, reflect.String, false},
@@ -1,6 +1,10 @@
 package database
 
 import (
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
 	"goyave.dev/goyave/v3/validation"
 )
 
@@ -8,20 +12,150 @@ import (
 
 func init() {
 	validation.AddRule("unique", &validation.RuleDefinition{
-		Function:           validateUnique,
+		ContextFunction:    validateUnique,
+		RequiredParameters: 1,
+	})
+	validation.AddRule("exists", &validation.RuleDefinition{
+		ContextFunction:    validateExists,
 		RequiredParameters: 1,
 	})
 }
 
-func validateUnique(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
-	column := field
-	if len(parameters) >= 2 {
-		column = parameters[1]
+// paramRequest is implemented by "*goyave.Request". This interface is used
+// to resolve route parameters without depending on the goyave package.
+type paramRequest interface {
+	Param(name string) string
+}
+
+// validateUnique checks that there is no record having the field value
+// in the given table.
+//
+//  unique:table[,column[,except[,exceptColumn]]]
+//
+// The "except" parameter is the identifier of a record to ignore, usually the
+// record being updated. It can reference a route parameter using the "{param}"
+// syntax. The "exceptColumn" defaults to "id".
+//  "email": {"required", "email", "unique:users,email,{id}"}
+//
+// If the value is an array, all values are checked in a single query.
+func validateUnique(ctx *validation.Context) bool {
+	query, values, ok := validationQuery(ctx)
+	if !ok {
+		return false
+	}
+	if values != nil && len(values) == 0 {
+		return true
 	}
 
 	count := int64(0)
-	if err := Conn().Table(parameters[0]).Where(column+"= ?", value).Count(&count).Error; err != nil {
+	if err := query.Count(&count).Error; err != nil {
 		panic(err)
 	}
 	return count == 0
 }
+
+// validateExists checks that there is a record having the field value
+// in the given table. Useful to validate foreign keys.
+//
+//  exists:table[,column]
+//
+// If the value is an array, all values are checked in a single query.
+func validateExists(ctx *validation.Context) bool {
+	query, values, ok := validationQuery(ctx)
+	if !ok {
+		return false
+	}
+	if values == nil {
+		count := int64(0)
+		if err := query.Count(&count).Error; err != nil {
+			panic(err)
+		}
+		return count > 0
+	}
+
+	distinct := make(map[interface{}]struct{}, len(values))
+	for _, v := range values {
+		distinct[v] = struct{}{}
+	}
+	if len(distinct) == 0 {
+		return true
+	}
+
+	count := int64(0)
+	if err := query.Distinct(validationColumn(ctx)).Count(&count).Error; err != nil {
+		panic(err)
+	}
+	return count == int64(len(distinct))
+}
+
+// validationQuery builds the query used by the "unique" and "exists" rules.
+// If the value is an array, the query uses "WHERE IN" and the array values
+// are returned. Returns false if the array contains values that cannot be
+// used in a query.
+func validationQuery(ctx *validation.Context) (*gorm.DB, []interface{}, bool) {
+	db := Conn()
+	if ctx.Context != nil {
+		db = db.WithContext(ctx.Context)
+	}
+	column := validationColumn(ctx)
+	query := db.Table(ctx.Parameters[0])
+
+	var values []interface{}
+	if list := reflect.ValueOf(ctx.Value); list.Kind() == reflect.Slice {
+		values = make([]interface{}, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			v := list.Index(i).Interface()
+			if !isQueryable(v) {
+				return nil, nil, false
+			}
+			values = append(values, v)
+		}
+		query = query.Where(column+" IN ?", values)
+	} else {
+		query = query.Where(column+" = ?", ctx.Value)
+	}
+
+	if len(ctx.Parameters) >= 3 {
+		if except := resolveParameter(ctx, ctx.Parameters[2]); except != "" {
+			exceptColumn := "id"
+			if len(ctx.Parameters) >= 4 {
+				exceptColumn = ctx.Parameters[3]
+			}
+			query = query.Where(exceptColumn+" <> ?", except)
+		}
+	}
+	return query, values, true
+}
+
+// validationColumn returns the column name given as second parameter, or
+// the name of the field under validation without its prefix.
+func validationColumn(ctx *validation.Context) string {
+	if len(ctx.Parameters) >= 2 {
+		return ctx.Parameters[1]
+	}
+	column := ctx.Field
+	if i := strings.LastIndex(column, "."); i != -1 {
+		column = column[i+1:]
+	}
+	return column
+}
+
+// resolveParameter returns the value of the route parameter if the given
+// parameter uses the "{param}" syntax, or the parameter itself.
+func resolveParameter(ctx *validation.Context, parameter string) string {
+	if strings.HasPrefix(parameter, "{") && strings.HasSuffix(parameter, "}") {
+		if request, ok := ctx.Request.(paramRequest); ok {
+			return request.Param(parameter[1 : len(parameter)-1])
+		}
+		return ""
+	}
+	return parameter
+}
+
+func isQueryable(value interface{}) bool {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Struct, reflect.Invalid:
+		return false
+	}
+	return true
+}
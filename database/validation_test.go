@@ -1,12 +1,15 @@
 package database
 
 import (
+	"context"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
 	"gorm.io/driver/mysql"
 	"goyave.dev/goyave/v3/config"
+	"goyave.dev/goyave/v3/validation"
 )
 
 type ValidationTestSuite struct {
@@ -25,6 +28,25 @@ func (suite *ValidationTestSuite) SetupSuite() {
 	}
 }
 
+type validationTestRequest struct {
+	params map[string]string
+}
+
+func (r *validationTestRequest) Param(name string) string {
+	return r.params[name]
+}
+
+func (suite *ValidationTestSuite) context(field string, value interface{}, parameters ...string) *validation.Context {
+	return &validation.Context{
+		Context:    context.Background(),
+		Request:    &validationTestRequest{params: map[string]string{"id": "1"}},
+		Field:      field,
+		Value:      value,
+		Parameters: parameters,
+		Data:       map[string]interface{}{},
+	}
+}
+
 func (suite *ValidationTestSuite) TestValidateUnique() {
 	ClearRegisteredModels()
 	RegisterModel(&User{})
@@ -39,15 +61,63 @@ func (suite *ValidationTestSuite) TestValidateUnique() {
 	db.Create(user)
 	defer db.Migrator().DropTable(user)
 
-	suite.False(validateUnique("email", "hugh@example.org", []string{"users"}, map[string]interface{}{}))
-	suite.False(validateUnique("email", "hugh@example.org", []string{"users", "email"}, map[string]interface{}{}))
-	suite.True(validateUnique("email", "hugh2@example.org", []string{"users"}, map[string]interface{}{}))
-	suite.True(validateUnique("email", "hugh2@example.org", []string{"users", "email"}, map[string]interface{}{}))
-	suite.True(validateUnique("email", "hugh@example.org", []string{"users", "name"}, map[string]interface{}{}))
+	suite.False(validateUnique(suite.context("email", "hugh@example.org", "users")))
+	suite.False(validateUnique(suite.context("email", "hugh@example.org", "users", "email")))
+	suite.True(validateUnique(suite.context("email", "hugh2@example.org", "users")))
+	suite.True(validateUnique(suite.context("email", "hugh2@example.org", "users", "email")))
+	suite.True(validateUnique(suite.context("email", "hugh@example.org", "users", "name")))
+	suite.False(validateUnique(suite.context("user.email", "hugh@example.org", "users")))
+
+	// Except
+	suite.True(validateUnique(suite.context("email", "hugh@example.org", "users", "email", strconv.Itoa(int(user.ID)))))
+	suite.False(validateUnique(suite.context("email", "hugh@example.org", "users", "email", "0")))
+	suite.True(validateUnique(suite.context("email", "hugh@example.org", "users", "email", "Hugh", "name")))
+	suite.True(validateUnique(suite.context("email", "hugh@example.org", "users", "email", "{id}")))
+	suite.False(validateUnique(suite.context("email", "hugh@example.org", "users", "email", "{notaparam}")))
+	ctx := suite.context("email", "hugh@example.org", "users", "email", "{id}")
+	ctx.Request = nil
+	suite.False(validateUnique(ctx))
+
+	// Arrays
+	suite.False(validateUnique(suite.context("emails", []string{"hugh2@example.org", "hugh@example.org"}, "users", "email")))
+	suite.True(validateUnique(suite.context("emails", []string{"hugh2@example.org", "hugh3@example.org"}, "users", "email")))
+	suite.True(validateUnique(suite.context("emails", []string{}, "users", "email")))
+	suite.False(validateUnique(suite.context("emails", []interface{}{map[string]interface{}{}}, "users", "email")))
+
+	// model not found
+	suite.Panics(func() {
+		validateUnique(suite.context("email", "hugh@example.org", "not a model", "email"))
+	})
+}
+
+func (suite *ValidationTestSuite) TestValidateExists() {
+	ClearRegisteredModels()
+	RegisterModel(&User{})
+	Migrate()
+	defer ClearRegisteredModels()
+
+	db := Conn()
+	users := []*User{
+		{Name: "Hugh", Email: "hugh@example.org"},
+		{Name: "Jane", Email: "jane@example.org"},
+	}
+	db.Create(users)
+	defer db.Migrator().DropTable(&User{})
+
+	suite.True(validateExists(suite.context("email", "hugh@example.org", "users")))
+	suite.True(validateExists(suite.context("user_email", "hugh@example.org", "users", "email")))
+	suite.False(validateExists(suite.context("email", "hugh2@example.org", "users")))
+	suite.True(validateExists(suite.context("user_id", float64(users[0].ID), "users", "id")))
+
+	// Arrays
+	suite.True(validateExists(suite.context("user_ids", []float64{float64(users[0].ID), float64(users[1].ID), float64(users[0].ID)}, "users", "id")))
+	suite.False(validateExists(suite.context("user_ids", []float64{float64(users[0].ID), 0}, "users", "id")))
+	suite.True(validateExists(suite.context("user_ids", []float64{}, "users", "id")))
+	suite.False(validateExists(suite.context("user_ids", []interface{}{[]string{}}, "users", "id")))
 
 	// model not found
 	suite.Panics(func() {
-		validateUnique("email", "hugh@example.org", []string{"not a model", "email"}, map[string]interface{}{})
+		validateExists(suite.context("email", "hugh@example.org", "not a model", "email"))
 	})
 }
 
@@ -0,0 +1,200 @@
+package goyave
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// BodyDecoder decodes request bodies of a specific media type
+// into request data.
+type BodyDecoder struct {
+
+	// Decode converts the given raw body into a map. The result is merged
+	// with the query parameters and becomes the request data.
+	// Returning a nil map or an error makes the request data nil.
+	Decode func(body []byte) (map[string]interface{}, error)
+
+	// Typed is true if the decoded values keep their type (numbers, booleans,
+	// arrays), like JSON. If false, the values are considered raw strings like
+	// in url-encoded forms, and single values are converted to arrays on
+	// validation if needed.
+	Typed bool
+}
+
+var (
+	bodyDecoders = map[string]*BodyDecoder{
+		"application/json":        {Decode: decodeJSON, Typed: true},
+		"application/xml":         {Decode: decodeXML, Typed: false},
+		"text/xml":                {Decode: decodeXML, Typed: false},
+		"application/msgpack":     {Decode: decodeMsgpack, Typed: true},
+		"application/x-msgpack":   {Decode: decodeMsgpack, Typed: true},
+		"application/vnd.msgpack": {Decode: decodeMsgpack, Typed: true},
+		"application/cbor":        {Decode: decodeCBOR, Typed: true},
+	}
+	bodyDecodersMutex = &sync.RWMutex{}
+
+	cborDecMode cbor.DecMode
+)
+
+func init() {
+	mode, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	cborDecMode = mode
+}
+
+// RegisterBodyDecoder registers a body decoder for the given media type
+// ("application/xml" for example). If a decoder is already registered for this
+// media type, it is replaced.
+//
+// The decoder is also used for media types with a structured syntax suffix
+// matching its subtype if there is no decoder registered specifically for them.
+// For example, the "application/json" decoder is used for "application/problem+json".
+//
+// Requests having a non-empty body with a media type that has no decoder and that
+// is not a form ("application/x-www-form-urlencoded" or "multipart/form-data")
+// are rejected with "415 Unsupported Media Type".
+func RegisterBodyDecoder(mediaType string, decoder *BodyDecoder) {
+	bodyDecodersMutex.Lock()
+	defer bodyDecodersMutex.Unlock()
+	bodyDecoders[strings.ToLower(mediaType)] = decoder
+}
+
+// findBodyDecoder returns the body decoder for the given Content-Type
+// header value, or nil if there is none.
+func findBodyDecoder(contentType string) *BodyDecoder {
+	mediaType := parseMediaType(contentType)
+	bodyDecodersMutex.RLock()
+	defer bodyDecodersMutex.RUnlock()
+	if decoder, ok := bodyDecoders[mediaType]; ok {
+		return decoder
+	}
+	if i := strings.LastIndex(mediaType, "+"); i != -1 {
+		if j := strings.Index(mediaType, "/"); j != -1 && j < i {
+			return bodyDecoders[mediaType[:j+1]+mediaType[i+1:]]
+		}
+	}
+	return nil
+}
+
+// parseMediaType returns the lowercase media type without
+// parameters of the given Content-Type header value.
+func parseMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		if i := strings.Index(contentType, ";"); i != -1 {
+			contentType = contentType[:i]
+		}
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+func decodeJSON(body []byte) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	err := json.Unmarshal(body, &data)
+	return data, err
+}
+
+func decodeMsgpack(body []byte) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	err := msgpack.Unmarshal(body, &data)
+	return data, err
+}
+
+func decodeCBOR(body []byte) (map[string]interface{}, error) {
+	var data map[string]interface{}
+	err := cborDecMode.Unmarshal(body, &data)
+	return data, err
+}
+
+// decodeXML converts an XML document into a map. The children of the root
+// element become the fields of the map:
+//  - Elements without children are converted to strings
+//  - Elements with children are converted to maps
+//  - Repeated elements are converted to slices
+//  - Attributes are converted to fields of their element
+func decodeXML(body []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if err == io.EOF {
+				return nil, errors.New("XML document has no root element")
+			}
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			if data, ok := value.(map[string]interface{}); ok {
+				return data, nil
+			}
+			return map[string]interface{}{}, nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	var fields map[string]interface{}
+	if len(start.Attr) > 0 {
+		fields = make(map[string]interface{}, len(start.Attr))
+		for _, attr := range start.Attr {
+			fields[attr.Name.Local] = attr.Value
+		}
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			value, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			if fields == nil {
+				fields = make(map[string]interface{}, 5)
+			}
+			name := t.Name.Local
+			if existing, ok := fields[name]; ok {
+				if list, ok := existing.([]interface{}); ok {
+					fields[name] = append(list, value)
+				} else {
+					fields[name] = []interface{}{existing, value}
+				}
+			} else {
+				fields[name] = value
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if fields != nil {
+				return fields, nil
+			}
+			return text.String(), nil
+		}
+	}
+}
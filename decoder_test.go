@@ -0,0 +1,146 @@
+package goyave
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/suite"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+type DecoderTestSuite struct {
+	suite.Suite
+}
+
+func (suite *DecoderTestSuite) TestFindBodyDecoder() {
+	suite.NotNil(findBodyDecoder("application/json"))
+	suite.NotNil(findBodyDecoder("application/json; charset=utf-8"))
+	suite.NotNil(findBodyDecoder("Application/JSON"))
+	suite.NotNil(findBodyDecoder("application/problem+json"))
+	suite.NotNil(findBodyDecoder("application/atom+xml"))
+	suite.NotNil(findBodyDecoder("text/xml"))
+	suite.NotNil(findBodyDecoder("application/msgpack"))
+	suite.NotNil(findBodyDecoder("application/cbor"))
+	suite.Nil(findBodyDecoder("text/plain"))
+	suite.Nil(findBodyDecoder("application/x-www-form-urlencoded"))
+	suite.Nil(findBodyDecoder("application/+"))
+	suite.Nil(findBodyDecoder(""))
+}
+
+func (suite *DecoderTestSuite) TestRegisterBodyDecoder() {
+	decoder := &BodyDecoder{
+		Decode: func(body []byte) (map[string]interface{}, error) {
+			return map[string]interface{}{"body": string(body)}, nil
+		},
+	}
+	RegisterBodyDecoder("Text/Plain", decoder)
+	defer func() {
+		bodyDecodersMutex.Lock()
+		delete(bodyDecoders, "text/plain")
+		bodyDecodersMutex.Unlock()
+	}()
+
+	suite.Same(decoder, findBodyDecoder("text/plain; charset=utf-8"))
+}
+
+func (suite *DecoderTestSuite) TestParseMediaType() {
+	suite.Equal("application/json", parseMediaType("application/json"))
+	suite.Equal("application/json", parseMediaType("Application/JSON; charset=utf-8"))
+	suite.Equal("application/json", parseMediaType(" application/json ;;"))
+	suite.Equal("", parseMediaType(""))
+}
+
+func (suite *DecoderTestSuite) TestDecodeXML() {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<user id="12">
+	<name>Jane</name>
+	<age>30</age>
+	<tags><tag>a</tag><tag>b</tag></tags>
+	<address city="Paris"><street>Main street</street></address>
+	<empty/>
+</user>`
+	data, err := decodeXML([]byte(body))
+	suite.Nil(err)
+	suite.Equal(map[string]interface{}{
+		"id":   "12",
+		"name": "Jane",
+		"age":  "30",
+		"tags": map[string]interface{}{
+			"tag": []interface{}{"a", "b"},
+		},
+		"address": map[string]interface{}{
+			"city":   "Paris",
+			"street": "Main street",
+		},
+		"empty": "",
+	}, data)
+
+	data, err = decodeXML([]byte("<root>text</root>"))
+	suite.Nil(err)
+	suite.Equal(map[string]interface{}{}, data)
+
+	data, err = decodeXML([]byte("<root><unclosed></root>"))
+	suite.NotNil(err)
+	suite.Nil(data)
+
+	data, err = decodeXML([]byte(""))
+	suite.NotNil(err)
+	suite.Nil(data)
+}
+
+func (suite *DecoderTestSuite) TestDecodeMsgpack() {
+	body, err := msgpack.Marshal(map[string]interface{}{"name": "Jane", "age": 30, "tags": []string{"a", "b"}})
+	if err != nil {
+		panic(err)
+	}
+	data, err := decodeMsgpack(body)
+	suite.Nil(err)
+	suite.Equal("Jane", data["name"])
+	suite.EqualValues(30, data["age"])
+	suite.Equal([]interface{}{"a", "b"}, data["tags"])
+
+	data, err = decodeMsgpack([]byte{0xc1})
+	suite.NotNil(err)
+	suite.Nil(data)
+}
+
+func (suite *DecoderTestSuite) TestDecodeCBOR() {
+	body, err := cbor.Marshal(map[string]interface{}{
+		"name":    "Jane",
+		"age":     30,
+		"address": map[string]interface{}{"city": "Paris"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	data, err := decodeCBOR(body)
+	suite.Nil(err)
+	suite.Equal("Jane", data["name"])
+	suite.EqualValues(30, data["age"])
+	suite.Equal(map[string]interface{}{"city": "Paris"}, data["address"])
+
+	data, err = decodeCBOR([]byte{0xff})
+	suite.NotNil(err)
+	suite.Nil(data)
+}
+
+func (suite *DecoderTestSuite) TestDecodeBody() {
+	request := &Request{Data: map[string]interface{}{"query": "param"}}
+	decoder := &BodyDecoder{Decode: decodeJSON}
+	decodeBody(request, decoder, []byte(`{"field":"value"}`))
+	suite.Equal(map[string]interface{}{"query": "param", "field": "value"}, request.Data)
+
+	decodeBody(request, decoder, []byte(`null`))
+	suite.Nil(request.Data)
+
+	request.Data = map[string]interface{}{}
+	decodeBody(request, &BodyDecoder{Decode: func(body []byte) (map[string]interface{}, error) {
+		return nil, errors.New("test error")
+	}}, []byte(`{}`))
+	suite.Nil(request.Data)
+}
+
+func TestDecoderTestSuite(t *testing.T) {
+	suite.Run(t, new(DecoderTestSuite))
+}
@@ -189,11 +189,14 @@ func IsMaintenanceEnabled() bool {
 	return maintenanceEnabled
 }
 
-// GetRoute get a named route.
-// Returns nil if the route doesn't exist.
+// GetRoute get a named route from the main router or any of its subrouters.
+// Returns nil if the route doesn't exist or if the server is not started.
 func GetRoute(name string) *Route {
-	mutex.Lock()
-	defer mutex.Unlock()
+	mutex.RLock()
+	defer mutex.RUnlock()
+	if router == nil {
+		return nil
+	}
 	return router.namedRoutes[name]
 }
 
@@ -262,6 +265,19 @@ func getHost(protocol string) string {
 }
 
 func getAddress(protocol string) string {
+	host := config.GetString("server.domain")
+	if len(host) == 0 {
+		host = config.GetString("server.host")
+		if host == "0.0.0.0" {
+			host = "127.0.0.1"
+		}
+	}
+	return getAddressForHost(protocol, host)
+}
+
+// getAddressForHost returns the address of the application using the given
+// host, including the port if it isn't the default one for the protocol.
+func getAddressForHost(protocol string, host string) string {
 	var shouldShowPort bool
 	var port string
 	if protocol == "https" {
@@ -273,13 +289,6 @@ func getAddress(protocol string) string {
 		port = strconv.Itoa(p)
 		shouldShowPort = p != 80
 	}
-	host := config.GetString("server.domain")
-	if len(host) == 0 {
-		host = config.GetString("server.host")
-		if host == "0.0.0.0" {
-			host = "127.0.0.1"
-		}
-	}
 
 	if shouldShowPort {
 		host += ":" + port
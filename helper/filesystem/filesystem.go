@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"io/fs"
 	"net/http"
 	"os"
 	"strconv"
@@ -36,6 +37,23 @@ func GetMIMEType(file string) (string, int64) {
 		panic(err)
 	}
 	defer f.Close()
+	return detectMIMEType(file, f)
+}
+
+// GetMIMETypeFS works like "GetMIMEType" but reads the file from the given filesystem.
+//
+// If the file cannot be opened, panics. You should check if the
+// file exists, using "filesystem.FileExistsFS()", before calling this function.
+func GetMIMETypeFS(fsys fs.FS, file string) (string, int64) {
+	f, err := fsys.Open(file)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	return detectMIMEType(file, f)
+}
+
+func detectMIMEType(file string, f fs.File) (string, int64) {
 	buffer := make([]byte, 512)
 
 	_, errRead := f.Read(buffer)
@@ -83,6 +101,24 @@ func IsDirectory(path string) bool {
 	return false
 }
 
+// FileExistsFS returns true if the file at the given path exists in the given
+// filesystem and is readable. Returns false if the given file is a directory.
+func FileExistsFS(fsys fs.FS, file string) bool {
+	if stats, err := fs.Stat(fsys, file); err == nil {
+		return !stats.IsDir()
+	}
+	return false
+}
+
+// IsDirectoryFS returns true if the file at the given path exists in the given
+// filesystem, is a directory and is readable.
+func IsDirectoryFS(fsys fs.FS, path string) bool {
+	if stats, err := fs.Stat(fsys, path); err == nil {
+		return stats.IsDir()
+	}
+	return false
+}
+
 // Delete the file at the given path.
 //
 // To avoid panics, you should check if the file exists.
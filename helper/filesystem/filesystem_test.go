@@ -12,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -99,6 +100,20 @@ func TestGetMIMEType(t *testing.T) {
 	})
 }
 
+func TestGetMIMETypeFS(t *testing.T) {
+	fsys := os.DirFS(toAbsolutePath("resources"))
+	mime, size := GetMIMETypeFS(fsys, "img/logo/goyave_16.png")
+	assert.Equal(t, "image/png", mime)
+	assert.Equal(t, int64(716), size)
+
+	mime, _ = GetMIMETypeFS(fstest.MapFS{"app.css": {Data: []byte("body{ margin:0; }")}}, "app.css")
+	assert.Equal(t, "text/css", mime)
+
+	assert.Panics(t, func() {
+		GetMIMETypeFS(fsys, "doesn't exist")
+	})
+}
+
 func TestFileExists(t *testing.T) {
 	assert.True(t, FileExists(toAbsolutePath("resources/img/logo/goyave_16.png")))
 	assert.False(t, FileExists(toAbsolutePath("doesn't exist")))
@@ -110,6 +125,20 @@ func TestIsDirectory(t *testing.T) {
 	assert.False(t, IsDirectory(toAbsolutePath("doesn't exist")))
 }
 
+func TestFileExistsFS(t *testing.T) {
+	fsys := os.DirFS(toAbsolutePath("resources"))
+	assert.True(t, FileExistsFS(fsys, "img/logo/goyave_16.png"))
+	assert.False(t, FileExistsFS(fsys, "img/logo"))
+	assert.False(t, FileExistsFS(fsys, "doesn't exist"))
+}
+
+func TestIsDirectoryFS(t *testing.T) {
+	fsys := os.DirFS(toAbsolutePath("resources"))
+	assert.True(t, IsDirectoryFS(fsys, "img/logo"))
+	assert.False(t, IsDirectoryFS(fsys, "img/logo/goyave_16.png"))
+	assert.False(t, IsDirectoryFS(fsys, "doesn't exist"))
+}
+
 func TestSaveDelete(t *testing.T) {
 	file := createTestFiles("resources/img/logo/goyave_16.png")[0]
 	actualName := file.Save(toAbsolutePath("."), "saved.png")
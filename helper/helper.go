@@ -85,6 +85,9 @@ var multiValuesHeaderRegex *regexp.Regexp
 // quality values into account. The result is a slice of values sorted
 // according to the order of priority.
 //
+// Parameters other than the quality value (such as "level=1") are ignored.
+// Values having a malformed quality value are left out.
+//
 // See: https://developer.mozilla.org/en-US/docs/Glossary/Quality_values
 //
 // For the following header:
@@ -93,34 +96,42 @@ var multiValuesHeaderRegex *regexp.Regexp
 //  [{text/html 1} {*/* 0.7} {text/* 0.5}]
 func ParseMultiValuesHeader(header string) []HeaderValue {
 	if multiValuesHeaderRegex == nil {
-		multiValuesHeaderRegex = regexp.MustCompile(`^q=([01]\.[0-9]{1,3})$`)
+		// RFC 7231 section 5.3.1
+		multiValuesHeaderRegex = regexp.MustCompile(`^(?:0(?:\.[0-9]{0,3})?|1(?:\.0{0,3})?)$`)
 	}
 	split := strings.Split(header, ",")
 	values := make([]HeaderValue, 0, len(split))
 
 	for _, v := range split {
-		val := HeaderValue{}
-		if i := strings.Index(v, ";"); i != -1 {
-			// Parse priority
-			q := v[i+1:]
-
-			sub := multiValuesHeaderRegex.FindStringSubmatch(q)
-			priority := 0.0
-			if len(sub) > 1 {
-				if p, err := strconv.ParseFloat(sub[1], 64); err == nil {
-					priority = p
-				}
-			}
-			// Priority set to 0 if the quality value cannot be parsed
-			val.Priority = priority
+		params := strings.Split(v, ";")
+		val := HeaderValue{
+			Value:    strings.TrimSpace(params[0]),
+			Priority: 1,
+		}
+		if val.Value == "" {
+			continue
+		}
 
-			val.Value = strings.Trim(v[:i], " ")
-		} else {
-			val.Value = strings.Trim(v, " ")
-			val.Priority = 1
+		valid := true
+		for _, param := range params[1:] {
+			name, q := param, ""
+			if i := strings.Index(param, "="); i != -1 {
+				name, q = param[:i], strings.TrimSpace(param[i+1:])
+			}
+			if !strings.EqualFold(strings.TrimSpace(name), "q") {
+				continue
+			}
+			if !multiValuesHeaderRegex.MatchString(q) {
+				valid = false
+				break
+			}
+			val.Priority, _ = strconv.ParseFloat(q, 64)
+			break // Parameters after the quality value are extensions
 		}
 
-		values = append(values, val)
+		if valid {
+			values = append(values, val)
+		}
 	}
 
 	sort.Sort(byPriority(values))
@@ -127,6 +127,22 @@ func TestParseMultiValuesHeader(t *testing.T) {
 	expected = []HeaderValue{{Value: "fr", Priority: 0.3}}
 	result = ParseMultiValuesHeader("fr;q=0.3")
 	assert.True(t, SliceEqual(expected, result))
+
+	expected = []HeaderValue{
+		{Value: "text/html", Priority: 1},
+		{Value: "application/xml", Priority: 1},
+		{Value: "application/json", Priority: 0.9},
+		{Value: "text/plain", Priority: 0},
+	}
+	result = ParseMultiValuesHeader("text/html;level=1, application/json; q=0.9, application/xml;Q=1.000, text/plain; q=0")
+	assert.True(t, SliceEqual(expected, result))
+
+	// Malformed quality values
+	expected = []HeaderValue{{Value: "gzip", Priority: 0.5}}
+	result = ParseMultiValuesHeader("br;q=abc, gzip;q=0.5, deflate;q=1.5, zstd;q=0.1234, identity;q")
+	assert.True(t, SliceEqual(expected, result))
+
+	assert.Empty(t, ParseMultiValuesHeader(""))
 }
 
 func TestRemoveHiddenFields(t *testing.T) {
@@ -0,0 +1,77 @@
+package goyave
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// hostPattern a compiled host pattern, such as "{tenant}.example.com".
+// Unconstrained parameters match one or more characters up to the next dot.
+type hostPattern struct {
+	pattern string
+	parameterizable
+}
+
+// The static parts of the pattern are lowercased because hosts are case-insensitive.
+// The parameter names and patterns are kept as written.
+func compileHostPattern(pattern string, regexCache map[string]*regexp.Regexp) *hostPattern {
+	host := &hostPattern{}
+	host.pattern = host.lowerStatic(pattern)
+	host.compileParameters(host.pattern, regexCache)
+	for _, segment := range host.segments {
+		if segment.kind == segmentParam {
+			segment.kind = segmentHostParam
+		}
+	}
+	return host
+}
+
+// lowerStatic returns the given pattern with its static parts lowercased.
+func (h *hostPattern) lowerStatic(pattern string) string {
+	idxs, err := h.braceIndices(pattern)
+	if err != nil {
+		return pattern // Let compileParameters panic with the error
+	}
+	var builder strings.Builder
+	builder.Grow(len(pattern))
+	end := 0
+	for i := 0; i < len(idxs); i += 2 {
+		builder.WriteString(strings.ToLower(pattern[end:idxs[i]]))
+		end = idxs[i+1] + 1
+		builder.WriteString(pattern[idxs[i]:end])
+	}
+	builder.WriteString(strings.ToLower(pattern[end:]))
+	return builder.String()
+}
+
+// matchRequest checks if the host of the given request matches the pattern.
+// If it does, the host parameters are merged into the route match.
+// Always returns true if the pattern is nil.
+func (h *hostPattern) matchRequest(req *http.Request, match *routeMatch) bool {
+	if h == nil {
+		return true
+	}
+	_, values, ok := h.matchPath(requestHost(req), false)
+	if ok && len(values) > 0 {
+		match.mergeParams(h.makeParameters(values, h.parameters))
+	}
+	return ok
+}
+
+// build the host from the pattern, replacing the parameters
+// with the given values.
+func (h *hostPattern) build(parameters []string) string {
+	return h.fill(h.pattern, parameters)
+}
+
+// requestHost returns the lowercase host of the given request,
+// without port and trailing dot.
+func requestHost(req *http.Request) string {
+	host := req.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -0,0 +1,256 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type HostTestSuite struct {
+	TestSuite
+}
+
+func (suite *HostTestSuite) serve(router *Router, method, host, uri string) (int, string) {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(method, uri, nil)
+	req.Host = host
+	router.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, string(body)
+}
+
+func (suite *HostTestSuite) TestRequestHost() {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Host = "Acme.Example.com:8080"
+	suite.Equal("acme.example.com", requestHost(req))
+
+	req.Host = "example.com."
+	suite.Equal("example.com", requestHost(req))
+
+	req.Host = "[::1]:8080"
+	suite.Equal("::1", requestHost(req))
+}
+
+func (suite *HostTestSuite) TestMatchRequest() {
+	host := compileHostPattern("{tenant}.example.com", nil)
+	req := httptest.NewRequest("GET", "/", nil)
+
+	req.Host = "acme.example.com"
+	match := routeMatch{}
+	suite.True(host.matchRequest(req, &match))
+	suite.Equal(map[string]string{"tenant": "acme"}, match.parameters)
+
+	req.Host = "a.b.example.com"
+	match = routeMatch{}
+	suite.False(host.matchRequest(req, &match))
+	suite.Nil(match.parameters)
+
+	req.Host = "example.com"
+	suite.False(host.matchRequest(req, &routeMatch{}))
+
+	host = compileHostPattern("{sub:[a-z]+(?:\\.[a-z]+)*}.example.com", nil)
+	req.Host = "a.b.example.com"
+	match = routeMatch{}
+	suite.True(host.matchRequest(req, &match))
+	suite.Equal(map[string]string{"sub": "a.b"}, match.parameters)
+
+	req.Host = "42.example.com"
+	suite.False(host.matchRequest(req, &routeMatch{}))
+
+	var nilHost *hostPattern
+	suite.True(nilHost.matchRequest(req, &routeMatch{}))
+
+	suite.Panics(func() {
+		compileHostPattern("{tenant.example.com", nil)
+	})
+}
+
+func (suite *HostTestSuite) TestMixedCasePattern() {
+	host := compileHostPattern("API.{tenantID}.Example.com", nil)
+	suite.Equal("api.{tenantID}.example.com", host.pattern)
+	suite.Equal([]string{"tenantID"}, host.parameters)
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Host = "api.Acme.EXAMPLE.com"
+	match := routeMatch{}
+	suite.True(host.matchRequest(req, &match))
+	suite.Equal(map[string]string{"tenantID": "acme"}, match.parameters)
+
+	router := NewRouter()
+	admin := router.Group()
+	admin.Host("Admin.example.com")
+	suite.Equal("admin.example.com", admin.GetHost())
+	admin.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "admin")
+	})
+	api := router.Group()
+	api.Host("API.{tenant}.com")
+	api.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "api "+r.Params["tenant"])
+	})
+
+	status, body := suite.serve(router, "GET", "admin.example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("admin", body)
+
+	status, body = suite.serve(router, "GET", "ADMIN.Example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("admin", body)
+
+	status, body = suite.serve(router, "GET", "api.acme.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("api acme", body)
+}
+
+func (suite *HostTestSuite) TestRouterHost() {
+	router := NewRouter()
+	admin := router.Group()
+	admin.Host("admin.example.com")
+	admin.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "admin")
+	})
+
+	tenant := router.Group()
+	tenant.Host("{tenant}.example.com")
+	tenant.Get("/users/{id}", func(response *Response, r *Request) {
+		response.String(http.StatusOK, r.Params["tenant"]+" "+r.Params["id"])
+	})
+	tenant.Delete("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "delete "+r.Params["tenant"])
+	})
+
+	router.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "main")
+	})
+
+	status, body := suite.serve(router, "GET", "admin.example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("admin", body)
+
+	status, body = suite.serve(router, "GET", "acme.example.com:8080", "/users/42")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("acme 42", body)
+
+	status, body = suite.serve(router, "DELETE", "ACME.example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("delete acme", body)
+
+	status, body = suite.serve(router, "GET", "example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("main", body)
+
+	// The routes of the parent router are not reachable
+	// if a subrouter's host matched
+	status, _ = suite.serve(router, "GET", "acme.example.com", "/")
+	suite.Equal(http.StatusMethodNotAllowed, status)
+
+	status, _ = suite.serve(router, "GET", "example.com", "/users/42")
+	suite.Equal(http.StatusNotFound, status)
+
+	// Subrouters inherit the host
+	api := tenant.Subrouter("/api")
+	api.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "api "+r.Params["tenant"])
+	})
+	status, body = suite.serve(router, "GET", "acme.example.com", "/api")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("api acme", body)
+
+	status, _ = suite.serve(router, "GET", "example.com", "/api")
+	suite.Equal(http.StatusNotFound, status)
+
+	suite.Equal("{tenant}.example.com", tenant.GetHost())
+	suite.Empty(router.GetHost())
+
+	// Root router
+	root := NewRouter()
+	root.Host("example.com")
+	root.Get("/", func(response *Response, r *Request) {})
+	status, _ = suite.serve(root, "GET", "example.com", "/")
+	suite.Equal(http.StatusNoContent, status)
+	status, _ = suite.serve(root, "GET", "other.com", "/")
+	suite.Equal(http.StatusNotFound, status)
+}
+
+func (suite *HostTestSuite) TestRouteHost() {
+	router := NewRouter()
+	route := router.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "docs "+r.Params["version"])
+	}).Host("{version}.docs.example.com")
+	router.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "main")
+	})
+	router.Post("/", func(response *Response, r *Request) {}).Host("api.example.com")
+
+	status, body := suite.serve(router, "GET", "v3.docs.example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("docs v3", body)
+
+	status, body = suite.serve(router, "GET", "example.com", "/")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("main", body)
+
+	// The method doesn't match but neither does the host
+	status, body = suite.serve(router, "POST", "example.com", "/")
+	suite.Equal(http.StatusMethodNotAllowed, status)
+
+	status, _ = suite.serve(router, "POST", "api.example.com", "/")
+	suite.Equal(http.StatusNoContent, status)
+
+	suite.Same(route, route.Host("{version}.docs.example.com"))
+	suite.Equal("{version}.docs.example.com", route.GetHost())
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Host = "example.com"
+	match := routeMatch{currentPath: "/"}
+	suite.False(route.match(req, &match))
+	suite.Equal(errMatchNotFound, match.err)
+
+	req.Host = "v2.docs.example.com"
+	match = routeMatch{currentPath: "/"}
+	suite.True(route.match(req, &match))
+	suite.Equal(map[string]string{"version": "v2"}, match.parameters)
+
+	// Inherited from the closest router
+	group := router.Group()
+	group.Host("{tenant}.example.com")
+	sub := group.Subrouter("/admin")
+	sub.Host("admin.example.com")
+	suite.Equal("admin.example.com", sub.Get("/", func(response *Response, r *Request) {}).GetHost())
+	suite.Equal("{tenant}.example.com", group.Get("/", func(response *Response, r *Request) {}).GetHost())
+	suite.Empty(router.Get("/other", func(response *Response, r *Request) {}).GetHost())
+}
+
+func (suite *HostTestSuite) TestBuildURL() {
+	router := NewRouter()
+	tenant := router.Group()
+	tenant.Host("{tenant}.example.com")
+	route := tenant.Get("/users/{id}", func(response *Response, r *Request) {})
+	suite.Equal("http://acme.example.com:1235/users/42", route.BuildURL("acme", "42"))
+
+	suite.Panics(func() {
+		route.BuildURL()
+	})
+	suite.Panics(func() {
+		route.BuildURL("acme")
+	})
+	suite.Panics(func() {
+		route.BuildURL("acme", "42", "more")
+	})
+
+	route = router.Get("/", func(response *Response, r *Request) {}).Host("www.example.com")
+	suite.Equal("http://www.example.com:1235/", route.BuildURL())
+
+	route = router.Get("/{id}", func(response *Response, r *Request) {})
+	suite.Equal(BaseURL()+"/42", route.BuildURL("42"))
+}
+
+func TestHostSuite(t *testing.T) {
+	RunTest(t, new(HostTestSuite))
+}
@@ -152,6 +152,14 @@ var enUS language = language{
 			"object.array":                     "The :field values must be objects.",
 			"unique":                           "The :field has already been taken.",
 			"unique.array":                     "At least one of the :field values has already been taken.",
+			"exists":                           "The selected :field doesn't exist.",
+			"exists.array":                     "At least one of the :field values doesn't exist.",
+			"required_if":                      "The :field is required when the :other is :other_values.",
+			"required_unless":                  "The :field is required unless the :other is :other_values.",
+			"required_with":                    "The :field is required when :fields is present.",
+			"required_with_all":                "The :field is required when :fields are present.",
+			"required_without":                 "The :field is required when :fields is not present.",
+			"required_without_all":             "The :field is required when none of :fields are present.",
 		},
 		fields: map[string]attribute{
 			"email": {
@@ -3,8 +3,9 @@ package lang
 import (
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
+	"io/fs"
 	"os"
+	"path"
 	"strings"
 	"sync"
 
@@ -37,6 +38,7 @@ type language struct {
 }
 
 var languages map[string]language
+var languagesFS fs.FS
 var mutex = &sync.RWMutex{}
 
 func (l *language) clone() language {
@@ -72,27 +74,51 @@ func LoadDefault() {
 	languages["en-US"] = enUS.clone()
 }
 
+// SetFS set the filesystem "LoadAllAvailableLanguages" loads the language
+// directories from, instead of the "resources/lang" directory. Use "fs.Sub"
+// to use a subdirectory.
+//
+//  //go:embed resources/lang
+//  var langs embed.FS
+//
+//  sub, _ := fs.Sub(langs, "resources/lang")
+//  lang.SetFS(sub)
+//
+// Passing nil restores the default behavior.
+func SetFS(fsys fs.FS) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	languagesFS = fsys
+}
+
 // LoadAllAvailableLanguages loads every language directory
-// in the "resources/lang" directory if it exists.
+// in the "resources/lang" directory if it exists, or in the
+// filesystem set with "SetFS".
 func LoadAllAvailableLanguages() {
 	mutex.Lock()
 	defer mutex.Unlock()
-	sep := string(os.PathSeparator)
-	workingDir, err := os.Getwd()
-	if err != nil {
-		panic(err)
-	}
-	langDirectory := workingDir + sep + "resources" + sep + "lang" + sep
-	if filesystem.IsDirectory(langDirectory) {
-		files, err := ioutil.ReadDir(langDirectory)
+	fsys := languagesFS
+	if fsys == nil {
+		sep := string(os.PathSeparator)
+		workingDir, err := os.Getwd()
 		if err != nil {
 			panic(err)
 		}
+		langDirectory := workingDir + sep + "resources" + sep + "lang" + sep
+		if !filesystem.IsDirectory(langDirectory) {
+			return
+		}
+		fsys = os.DirFS(langDirectory)
+	}
 
-		for _, f := range files {
-			if f.IsDir() {
-				load(f.Name(), langDirectory+sep+f.Name())
-			}
+	files, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, f := range files {
+		if f.IsDir() {
+			load(f.Name(), fsys, f.Name())
 		}
 	}
 }
@@ -110,18 +136,29 @@ func Load(language, path string) {
 	mutex.Lock()
 	defer mutex.Unlock()
 	if filesystem.IsDirectory(path) {
-		load(language, path)
+		load(language, os.DirFS(path), ".")
+	} else {
+		panic(fmt.Sprintf("Failed loading language \"%s\", directory \"%s\" doesn't exist", language, path))
+	}
+}
+
+// LoadFS works like "Load" but reads the language directory
+// from the given filesystem.
+func LoadFS(fsys fs.FS, language, path string) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	if filesystem.IsDirectoryFS(fsys, path) {
+		load(language, fsys, path)
 	} else {
 		panic(fmt.Sprintf("Failed loading language \"%s\", directory \"%s\" doesn't exist", language, path))
 	}
 }
 
-func load(lang string, path string) {
+func load(lang string, fsys fs.FS, directory string) {
 	langStruct := language{}
-	sep := string(os.PathSeparator)
-	readLangFile(path+sep+"locale.json", &langStruct.lines)
-	readLangFile(path+sep+"rules.json", &langStruct.validation.rules)
-	readLangFile(path+sep+"fields.json", &langStruct.validation.fields)
+	readLangFile(fsys, path.Join(directory, "locale.json"), &langStruct.lines)
+	readLangFile(fsys, path.Join(directory, "rules.json"), &langStruct.validation.rules)
+	readLangFile(fsys, path.Join(directory, "fields.json"), &langStruct.validation.fields)
 
 	if existingLang, exists := languages[lang]; exists {
 		mergeLang(existingLang, langStruct)
@@ -130,9 +167,9 @@ func load(lang string, path string) {
 	}
 }
 
-func readLangFile(path string, dest interface{}) {
-	if filesystem.FileExists(path) {
-		langFile, _ := os.Open(path)
+func readLangFile(fsys fs.FS, path string, dest interface{}) {
+	if filesystem.FileExistsFS(fsys, path) {
+		langFile, _ := fsys.Open(path)
 		defer langFile.Close()
 
 		errParse := json.NewDecoder(langFile).Decode(&dest)
@@ -1,9 +1,11 @@
 package lang
 
 import (
+	"os"
 	"path"
 	"runtime"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/suite"
 	"goyave.dev/goyave/v3/config"
@@ -15,7 +17,7 @@ type LangTestSuite struct {
 
 func loadTestLang(lang string) {
 	_, filename, _, _ := runtime.Caller(1)
-	load(lang, path.Dir(filename)+"/../resources/lang/en-US")
+	load(lang, os.DirFS(path.Dir(filename)+"/../resources/lang"), "en-US")
 }
 
 func (suite *LangTestSuite) SetupSuite() {
@@ -84,7 +86,7 @@ func (suite *LangTestSuite) TestLoad() {
 
 	suite.Panics(func() {
 		dest := map[string]string{}
-		readLangFile("../resources/lang/invalid.json", &dest)
+		readLangFile(os.DirFS("../resources/lang"), "invalid.json", &dest)
 	})
 
 	// Ensure default lang is not changed
@@ -92,6 +94,43 @@ func (suite *LangTestSuite) TestLoad() {
 
 }
 
+func (suite *LangTestSuite) TestLoadFS() {
+	fsys := fstest.MapFS{
+		"lang/de-DE/locale.json":  {Data: []byte(`{"greetings": "Hallo"}`)},
+		"lang/de-DE/rules.json":   {Data: []byte(`{"required": "Das Feld :field ist erforderlich."}`)},
+		"lang/invalid/rules.json": {Data: []byte(`{"required": 1}`)},
+	}
+
+	suite.Panics(func() {
+		LoadFS(fsys, "notalanguagedir", "notalanguagepath")
+	})
+	suite.Panics(func() {
+		LoadFS(fsys, "invalid", "lang/invalid")
+	})
+
+	LoadFS(fsys, "de-DE", "lang/de-DE")
+	suite.Equal("Hallo", Get("de-DE", "greetings"))
+	suite.Equal("Das Feld :field ist erforderlich.", Get("de-DE", "validation.rules.required"))
+	delete(languages, "de-DE")
+	delete(languages, "invalid")
+}
+
+func (suite *LangTestSuite) TestSetFS() {
+	SetFS(fstest.MapFS{
+		"de-DE/locale.json": {Data: []byte(`{"greetings": "Hallo"}`)},
+		"README.md":         {Data: []byte("Not a language")},
+	})
+	defer func() {
+		SetFS(nil)
+		delete(languages, "de-DE")
+	}()
+
+	LoadAllAvailableLanguages()
+	suite.True(IsAvailable("de-DE"))
+	suite.Equal("Hallo", Get("de-DE", "greetings"))
+	suite.False(IsAvailable("README.md"))
+}
+
 func (suite *LangTestSuite) TestMerge() {
 	dst := language{
 		lines: map[string]string{"line": "line 1"},
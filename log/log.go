@@ -2,6 +2,7 @@ package log
 
 import (
 	"io"
+	"net/http"
 	"time"
 
 	"goyave.dev/goyave/v3"
@@ -26,6 +27,7 @@ type Writer struct {
 
 var _ io.Closer = (*Writer)(nil)
 var _ goyave.PreWriter = (*Writer)(nil)
+var _ http.Flusher = (*Writer)(nil)
 
 // NewWriter create a new LogWriter.
 // The given Request and Response will be used and passed to the given
@@ -55,6 +57,13 @@ func (w *Writer) Write(b []byte) (int, error) {
 	return w.writer.Write(b)
 }
 
+// Flush flushes the child writer if it implements http.Flusher.
+func (w *Writer) Flush() {
+	if flusher, ok := w.writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // Close the writer and its child ResponseWriter, flushing response
 // output to the logs.
 func (w *Writer) Close() error {
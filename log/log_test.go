@@ -132,6 +132,18 @@ func (suite *LogMiddlewareTestSuite) TestCloseChildWriter() {
 	})
 }
 
+func (suite *LogMiddlewareTestSuite) TestFlush() {
+	recorder := httptest.NewRecorder()
+	response := suite.CreateTestResponse(recorder)
+	request := suite.CreateTestRequest(httptest.NewRequest("GET", "/log", nil))
+	writer := NewWriter(response, request, CommonLogFormatter)
+	writer.Flush()
+	suite.True(recorder.Flushed)
+
+	writer.writer = &testWriter{Writer: recorder}
+	writer.Flush() // Child writer doesn't implement http.Flusher
+}
+
 func TestLogMiddlewareSuite(t *testing.T) {
 	goyave.RunTest(t, new(LogMiddlewareTestSuite))
 }
@@ -2,13 +2,11 @@ package goyave
 
 import (
 	"bytes"
-	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"runtime/debug"
-	"strings"
 
 	"goyave.dev/goyave/v3/config"
 	"goyave.dev/goyave/v3/helper/filesystem"
@@ -49,8 +47,13 @@ func recoveryMiddleware(next Handler) Handler {
 // If the parsing fails, the request's data is set to nil. If it succeeds
 // and there is no data, the request's data is set to an empty map.
 //
-// If the "Content-Type: application/json" header is set, the middleware
-// will attempt to unmarshal the request's body.
+// The request's body is decoded using the body decoder registered for the
+// media type of the "Content-Type" header (see "RegisterBodyDecoder()"). JSON, XML,
+// MessagePack and CBOR are supported out of the box, as well as url-encoded and
+// multipart forms. If there is no decoder for a non-empty body and the route has
+// validation rules, the middleware doesn't call "next()" and sets the response status
+// code to "415 Unsupported Media Type". If the route doesn't have validation rules,
+// the body is left untouched for the handler and the request's data is set to nil.
 //
 // This middleware doesn't drain the request body to maximize compatibility
 // with native handlers.
@@ -84,20 +87,35 @@ func parseRequestMiddleware(next Handler) Handler {
 				}
 
 				bodyBytes := bodyBuf.Bytes()
-				if strings.HasPrefix(contentType, "application/json") {
+				if decoder := findBodyDecoder(contentType); decoder != nil {
 					request.Data = make(map[string]interface{}, 10)
 					if err := parseQuery(request); err != nil {
 						request.Data = nil
 					} else {
-						if err := json.Unmarshal(bodyBytes, &request.Data); err != nil {
-							request.Data = nil
-						}
+						decodeBody(request, decoder, bodyBytes)
 					}
 					resetRequestBody(request, bodyBytes)
-				} else {
+				} else if isFormMediaType(parseMediaType(contentType)) {
 					resetRequestBody(request, bodyBytes)
 					request.Data = generateFlatMap(request.httpRequest, maxSize)
 					resetRequestBody(request, bodyBytes)
+				} else if len(bodyBytes) == 0 {
+					resetRequestBody(request, bodyBytes)
+					request.Data = make(map[string]interface{})
+					if err := parseQuery(request); err != nil {
+						request.Data = nil
+					}
+				} else {
+					resetRequestBody(request, bodyBytes)
+					if request.Rules != nil {
+						response.Status(http.StatusUnsupportedMediaType)
+						return
+					}
+					// The body is left for the handler to read
+					request.Data = make(map[string]interface{})
+					if err := parseQuery(request); err != nil {
+						request.Data = nil
+					}
 				}
 			}
 		}
@@ -106,6 +124,20 @@ func parseRequestMiddleware(next Handler) Handler {
 	}
 }
 
+// decodeBody decodes the given body using the given decoder and merges
+// the result into the request data. Sets the request data to nil if
+// the decoding failed.
+func decodeBody(request *Request, decoder *BodyDecoder, body []byte) {
+	data, err := decoder.Decode(body)
+	if err != nil || data == nil {
+		request.Data = nil
+		return
+	}
+	for k, v := range data {
+		request.Data[k] = v
+	}
+}
+
 func generateFlatMap(request *http.Request, maxSize int64) map[string]interface{} {
 	flatMap := make(map[string]interface{})
 	err := request.ParseMultipartForm(maxSize)
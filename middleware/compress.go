@@ -0,0 +1,356 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"goyave.dev/goyave/v3"
+	"goyave.dev/goyave/v3/helper"
+)
+
+// Encoder creates writers compressing data using a specific content encoding.
+type Encoder struct {
+
+	// Encoding the content encoding token, used in the "Accept-Encoding"
+	// and "Content-Encoding" headers. For example "gzip".
+	Encoding string
+
+	// NewWriter returns a writer compressing data written to it
+	// and writing the result to the given writer.
+	NewWriter func(w io.Writer) io.WriteCloser
+}
+
+// GzipEncoder returns a gzip encoder using the given compression level.
+// The level should be gzip.DefaultCompression, gzip.NoCompression, gzip.HuffmanOnly,
+// or any integer value between gzip.BestSpeed and gzip.BestCompression inclusive.
+func GzipEncoder(level int) *Encoder {
+	if level < gzip.HuffmanOnly || level > gzip.BestCompression {
+		panic(fmt.Errorf("gzip: invalid compression level: %d", level))
+	}
+	return &Encoder{
+		Encoding: "gzip",
+		NewWriter: func(w io.Writer) io.WriteCloser {
+			writer, _ := gzip.NewWriterLevel(w, level)
+			return writer
+		},
+	}
+}
+
+// BrotliEncoder returns a brotli encoder using the given compression level.
+// The level should be any integer value between brotli.BestSpeed and
+// brotli.BestCompression inclusive.
+func BrotliEncoder(level int) *Encoder {
+	if level < brotli.BestSpeed || level > brotli.BestCompression {
+		panic(fmt.Errorf("brotli: invalid compression level: %d", level))
+	}
+	return &Encoder{
+		Encoding: "br",
+		NewWriter: func(w io.Writer) io.WriteCloser {
+			return brotli.NewWriterLevel(w, level)
+		},
+	}
+}
+
+// ZstdEncoder returns a zstd encoder using the given compression level.
+// The level should be one of zstd.SpeedFastest, zstd.SpeedDefault,
+// zstd.SpeedBetterCompression or zstd.SpeedBestCompression.
+func ZstdEncoder(level zstd.EncoderLevel) *Encoder {
+	if level < zstd.SpeedFastest || level > zstd.SpeedBestCompression {
+		panic(fmt.Errorf("zstd: invalid compression level: %d", level))
+	}
+	return &Encoder{
+		Encoding: "zstd",
+		NewWriter: func(w io.Writer) io.WriteCloser {
+			writer, _ := zstd.NewWriter(w, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(1))
+			return writer
+		},
+	}
+}
+
+// CompressOptions options for the compression middleware.
+type CompressOptions struct {
+
+	// Encoders the supported encoders, in order of preference. When the client
+	// accepts several of them with the same quality value, the first one is used.
+	// Defaults to brotli, zstd and gzip with their default compression level.
+	Encoders []*Encoder
+
+	// MinLength responses with a body smaller than this number of bytes
+	// are not compressed. Defaults to 1024.
+	MinLength int
+
+	// ContentTypes the media types eligible for compression. Values ending with
+	// a slash ("text/") match all the subtypes of this type. Values starting with
+	// a plus sign ("+json") match structured syntax suffixes.
+	// Defaults to DefaultCompressContentTypes.
+	ContentTypes []string
+}
+
+// DefaultCompressContentTypes the media types compressed by default by the
+// compression middleware. Images (except SVG), videos, archives and other
+// already compressed formats are excluded.
+var DefaultCompressContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/x-javascript",
+	"application/xml",
+	"application/wasm",
+	"application/x-yaml",
+	"application/msgpack",
+	"application/cbor",
+	"image/svg+xml",
+	"+json",
+	"+xml",
+}
+
+const defaultCompressMinLength = 1024
+
+type compressWriter struct {
+	buffer      bytes.Buffer
+	encoder     *Encoder
+	writer      io.WriteCloser
+	childWriter io.Writer
+	response    *goyave.Response
+	options     *CompressOptions
+	decided     bool
+}
+
+func (w *compressWriter) PreWrite(b []byte) {
+	if w.decided {
+		if pr, ok := w.childWriter.(goyave.PreWriter); ok {
+			pr.PreWrite(b)
+		}
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		n, _ := w.buffer.Write(b)
+		if w.buffer.Len() < w.options.MinLength {
+			return n, nil
+		}
+		return n, w.decide()
+	}
+	if w.writer != nil {
+		return w.writer.Write(b)
+	}
+	return w.childWriter.Write(b)
+}
+
+// Flush writes the pending compressed data to the child writer
+// and flushes it if it implements http.Flusher. If the compression
+// was not decided yet, it is decided with the data buffered so far.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			goyave.ErrLogger.Println(err)
+		}
+	}
+	if flusher, ok := w.writer.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			goyave.ErrLogger.Println(err)
+		}
+	}
+	if flusher, ok := w.childWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Close() error {
+	var err error
+	if !w.decided && !w.response.Hijacked() {
+		err = w.decide()
+	}
+	if w.writer != nil {
+		if e := w.writer.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	if wr, ok := w.childWriter.(io.Closer); ok {
+		if e := wr.Close(); e != nil {
+			return e
+		}
+	}
+	return err
+}
+
+// decide whether the response should be compressed, release the
+// response header and write the buffered body.
+func (w *compressWriter) decide() error {
+	w.decided = true
+	w.response.ReleaseHeader()
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	b := w.buffer.Bytes()
+	header := w.response.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", http.DetectContentType(b))
+	}
+	if w.shouldCompress() {
+		header.Set("Content-Encoding", w.encoder.Encoding)
+		header.Del("Content-Length")
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			// The compressed body is not byte-for-byte equal to the original
+			header.Set("ETag", "W/"+etag)
+		}
+		w.writer = w.encoder.NewWriter(w.childWriter)
+	}
+
+	w.PreWrite(b)
+	status := w.response.GetStatus()
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.response.WriteHeader(status)
+	var err error
+	if w.writer != nil {
+		_, err = w.writer.Write(b)
+	} else {
+		_, err = w.childWriter.Write(b)
+	}
+	w.buffer.Reset()
+	return err
+}
+
+func (w *compressWriter) shouldCompress() bool {
+	header := w.response.Header()
+	status := w.response.GetStatus()
+	if w.buffer.Len() < w.options.MinLength ||
+		w.response.IsHeaderWritten() ||
+		status == http.StatusPartialContent ||
+		header.Get("Content-Encoding") != "" ||
+		header.Get("Content-Range") != "" {
+		return false
+	}
+	return isCompressible(header.Get("Content-Type"), w.options.ContentTypes)
+}
+
+func isCompressible(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range allowed {
+		switch {
+		case strings.HasSuffix(t, "/"):
+			if strings.HasPrefix(mediaType, t) {
+				return true
+			}
+		case strings.HasPrefix(t, "+"):
+			if strings.HasSuffix(mediaType, t) {
+				return true
+			}
+		case mediaType == t:
+			return true
+		}
+	}
+	return false
+}
+
+// negotiateEncoding returns the encoder having the highest quality value in the
+// given "Accept-Encoding" header value, or nil if none is acceptable or if the
+// client prefers uncompressed content ("identity").
+func negotiateEncoding(acceptEncoding string, encoders []*Encoder) *Encoder {
+	if acceptEncoding == "" {
+		return nil
+	}
+	values := helper.ParseMultiValuesHeader(acceptEncoding)
+	quality := func(encoding string) (float64, bool) {
+		for _, v := range values {
+			if strings.EqualFold(v.Value, encoding) {
+				return v.Priority, true
+			}
+		}
+		return 0, false
+	}
+	wildcard, hasWildcard := quality("*")
+
+	var best *Encoder
+	bestQuality := 0.0
+	for _, e := range encoders {
+		q, ok := quality(e.Encoding)
+		if !ok && hasWildcard {
+			q = wildcard
+		}
+		if q > bestQuality {
+			best = e
+			bestQuality = q
+		}
+	}
+
+	if identity, ok := quality("identity"); ok && identity > bestQuality {
+		return nil
+	}
+	return best
+}
+
+// Compress compresses HTTP responses using the encoding preferred by the
+// client according to the "Accept-Encoding" header and its quality values.
+// By default, brotli, zstd and gzip are supported, in this order of preference.
+//
+// The body is buffered until it reaches the minimum length, then compressed
+// only if its "Content-Type" is in the allowlist. Partial content and responses
+// already having a "Content-Encoding" are never compressed.
+// The "Vary: Accept-Encoding" header is always added.
+//
+// Strong ETags of compressed responses are weakened ("W/" prefix) because the
+// compressed body is not byte-for-byte equal to the uncompressed one.
+//
+// The options can be nil to use the defaults.
+//
+//  router.Middleware(middleware.Compress(&middleware.CompressOptions{
+//    Encoders:  []*middleware.Encoder{middleware.GzipEncoder(gzip.BestSpeed)},
+//    MinLength: 512,
+//  }))
+func Compress(options *CompressOptions) goyave.Middleware {
+	opts := CompressOptions{}
+	if options != nil {
+		opts = *options
+	}
+	if opts.Encoders == nil {
+		opts.Encoders = []*Encoder{
+			BrotliEncoder(brotli.DefaultCompression),
+			ZstdEncoder(zstd.SpeedDefault),
+			GzipEncoder(gzip.DefaultCompression),
+		}
+	}
+	if opts.MinLength <= 0 {
+		opts.MinLength = defaultCompressMinLength
+	}
+	if opts.ContentTypes == nil {
+		opts.ContentTypes = DefaultCompressContentTypes
+	}
+
+	return func(next goyave.Handler) goyave.Handler {
+		return func(response *goyave.Response, request *goyave.Request) {
+			response.Header().Add("Vary", "Accept-Encoding")
+			encoder := negotiateEncoding(request.Header().Get("Accept-Encoding"), opts.Encoders)
+			if encoder == nil || request.Header().Get("Upgrade") != "" {
+				next(response, request)
+				return
+			}
+
+			writer := &compressWriter{
+				encoder:     encoder,
+				childWriter: response.Writer(),
+				response:    response,
+				options:     &opts,
+			}
+			response.SetWriter(writer)
+			response.DeferHeader()
+
+			next(response, request)
+		}
+	}
+}
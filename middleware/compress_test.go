@@ -0,0 +1,325 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	"goyave.dev/goyave/v3"
+)
+
+type CompressMiddlewareTestSuite struct {
+	goyave.TestSuite
+}
+
+var compressTestBody = strings.Repeat("hello world ", 200)
+
+func (suite *CompressMiddlewareTestSuite) request(acceptEncoding string, options *CompressOptions, handler goyave.Handler) *http.Response {
+	rawRequest := httptest.NewRequest("GET", "/", nil)
+	if acceptEncoding != "" {
+		rawRequest.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	request := suite.CreateTestRequest(rawRequest)
+	return suite.Middleware(Compress(options), request, handler)
+}
+
+func (suite *CompressMiddlewareTestSuite) decode(result *http.Response) string {
+	var reader io.Reader
+	switch result.Header.Get("Content-Encoding") {
+	case "gzip":
+		r, err := gzip.NewReader(result.Body)
+		if err != nil {
+			panic(err)
+		}
+		reader = r
+	case "br":
+		reader = brotli.NewReader(result.Body)
+	case "zstd":
+		r, err := zstd.NewReader(result.Body)
+		if err != nil {
+			panic(err)
+		}
+		defer r.Close()
+		reader = r
+	default:
+		reader = result.Body
+	}
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		panic(err)
+	}
+	result.Body.Close()
+	return string(body)
+}
+
+func (suite *CompressMiddlewareTestSuite) TestNegotiateEncoding() {
+	br := BrotliEncoder(brotli.DefaultCompression)
+	zs := ZstdEncoder(zstd.SpeedDefault)
+	gz := GzipEncoder(gzip.DefaultCompression)
+	encoders := []*Encoder{br, zs, gz}
+
+	suite.Nil(negotiateEncoding("", encoders))
+	suite.Same(gz, negotiateEncoding("gzip", encoders))
+	suite.Same(br, negotiateEncoding("gzip, deflate, br", encoders))
+	suite.Same(zs, negotiateEncoding("gzip;q=0.5, zstd, br;q=0.8", encoders))
+	suite.Same(br, negotiateEncoding("*", encoders))
+	suite.Same(zs, negotiateEncoding("*;q=0.5, zstd, br;q=0", encoders))
+	suite.Same(gz, negotiateEncoding("*, br;q=0, zstd;q=0", encoders))
+	suite.Same(gz, negotiateEncoding("GZIP", encoders))
+	suite.Nil(negotiateEncoding("deflate", encoders))
+	suite.Nil(negotiateEncoding("gzip;q=0", encoders))
+	suite.Nil(negotiateEncoding("identity, gzip;q=0.5", encoders))
+	suite.Same(gz, negotiateEncoding("identity;q=0.5, gzip", encoders))
+	suite.Same(gz, negotiateEncoding("gzip; q=0.9, br; q=0", encoders))
+	suite.Same(zs, negotiateEncoding("gzip;q=0.5, zstd;q=1", encoders))
+	suite.Nil(negotiateEncoding("gzip; q=0", encoders))
+}
+
+func (suite *CompressMiddlewareTestSuite) TestIsCompressible() {
+	suite.True(isCompressible("text/html; charset=utf-8", DefaultCompressContentTypes))
+	suite.True(isCompressible("application/json", DefaultCompressContentTypes))
+	suite.True(isCompressible("application/problem+json", DefaultCompressContentTypes))
+	suite.True(isCompressible("image/svg+xml", DefaultCompressContentTypes))
+	suite.False(isCompressible("image/png", DefaultCompressContentTypes))
+	suite.False(isCompressible("application/zip", DefaultCompressContentTypes))
+	suite.False(isCompressible("", DefaultCompressContentTypes))
+}
+
+func (suite *CompressMiddlewareTestSuite) TestInvalidLevel() {
+	suite.Panics(func() { GzipEncoder(-3) })
+	suite.Panics(func() { BrotliEncoder(12) })
+	suite.Panics(func() { ZstdEncoder(zstd.EncoderLevel(10)) })
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompress() {
+	handler := func(response *goyave.Response, r *goyave.Request) {
+		response.Header().Set("Content-Length", "2400")
+		response.String(http.StatusCreated, compressTestBody)
+	}
+
+	for _, encoding := range []string{"br", "zstd", "gzip"} {
+		result := suite.request(encoding, nil, handler)
+		suite.Equal(http.StatusCreated, result.StatusCode)
+		suite.Equal(encoding, result.Header.Get("Content-Encoding"))
+		suite.Equal("Accept-Encoding", result.Header.Get("Vary"))
+		suite.Empty(result.Header.Get("Content-Length"))
+		suite.Equal("text/plain; charset=utf-8", result.Header.Get("Content-Type"))
+		suite.Equal(compressTestBody, suite.decode(result))
+	}
+
+	// Not accepted
+	result := suite.request("", nil, handler)
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	suite.Equal("Accept-Encoding", result.Header.Get("Vary"))
+	suite.Equal("2400", result.Header.Get("Content-Length"))
+	suite.Equal(compressTestBody, suite.decode(result))
+
+	// Written in several parts
+	result = suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.Header().Set("Content-Type", "application/json")
+		for i := 0; i < 200; i++ {
+			response.Write([]byte("hello world "))
+		}
+	})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("gzip", result.Header.Get("Content-Encoding"))
+	suite.Equal(compressTestBody, suite.decode(result))
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompressSkip() {
+	// Too small
+	result := suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, "hello world")
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	suite.Equal("hello world", suite.decode(result))
+
+	result = suite.request("gzip", &CompressOptions{MinLength: 5}, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, "hello world")
+	})
+	suite.Equal("gzip", result.Header.Get("Content-Encoding"))
+	suite.Equal("hello world", suite.decode(result))
+
+	// Content type not allowed
+	image := bytes.Repeat([]byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'}, 200)
+	result = suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.Write(image)
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	suite.Equal("image/png", result.Header.Get("Content-Type"))
+	suite.Equal(string(image), suite.decode(result))
+
+	result = suite.request("gzip", &CompressOptions{ContentTypes: []string{"application/json"}}, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, compressTestBody)
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+
+	// Already encoded
+	result = suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.Header().Set("Content-Encoding", "br")
+		response.String(http.StatusOK, compressTestBody)
+	})
+	suite.Equal("br", result.Header.Get("Content-Encoding"))
+	result.Body.Close()
+
+	// Partial content
+	result = suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusPartialContent, compressTestBody)
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	result.Body.Close()
+
+	// Empty
+	result = suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {})
+	suite.Equal(http.StatusNoContent, result.StatusCode)
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	result.Body.Close()
+
+	// Status handler
+	result = suite.request("gzip", &CompressOptions{MinLength: 1}, func(response *goyave.Response, r *goyave.Request) {
+		response.Status(http.StatusNotFound)
+	})
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+	suite.Equal("gzip", result.Header.Get("Content-Encoding"))
+	suite.Equal("{\"error\":\"Not Found\"}\n", suite.decode(result))
+
+	// Upgrade
+	rawRequest := httptest.NewRequest("GET", "/", nil)
+	rawRequest.Header.Set("Accept-Encoding", "gzip")
+	rawRequest.Header.Set("Upgrade", "websocket")
+	result = suite.Middleware(Compress(nil), suite.CreateTestRequest(rawRequest), func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, compressTestBody)
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	result.Body.Close()
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompressFlush() {
+	result := suite.request("gzip", nil, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, "hello ")
+		response.Flush()
+		suite.True(response.IsHeaderWritten())
+		response.String(http.StatusOK, "world")
+	})
+	suite.Empty(result.Header.Get("Content-Encoding")) // Decided with first part only
+	suite.Equal("hello world", suite.decode(result))
+
+	result = suite.request("gzip", &CompressOptions{MinLength: 1}, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, "hello ")
+		response.Flush()
+		response.String(http.StatusOK, "world")
+	})
+	suite.Equal("gzip", result.Header.Get("Content-Encoding"))
+	suite.Equal("hello world", suite.decode(result))
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompressFile() {
+	result := suite.request("br", &CompressOptions{MinLength: 1}, func(response *goyave.Response, r *goyave.Request) {
+		response.File("resources/test_file.txt")
+	})
+	content, err := ioutil.ReadFile("resources/test_file.txt")
+	if err != nil {
+		panic(err)
+	}
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("br", result.Header.Get("Content-Encoding"))
+	suite.Empty(result.Header.Get("Content-Length"))
+	suite.True(strings.HasPrefix(result.Header.Get("ETag"), "W/\""))
+	suite.Equal(string(content), suite.decode(result))
+
+	// Not compressed, the ETag stays strong
+	result = suite.request("", &CompressOptions{MinLength: 1}, func(response *goyave.Response, r *goyave.Request) {
+		response.File("resources/test_file.txt")
+	})
+	suite.Empty(result.Header.Get("Content-Encoding"))
+	suite.True(strings.HasPrefix(result.Header.Get("ETag"), "\""))
+	suite.Equal(string(content), suite.decode(result))
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompressWithETag() {
+	suite.RunServer(func(router *goyave.Router) {
+		router.Middleware(ETag())
+		router.Middleware(Compress(nil))
+		router.Route("GET", "/test", func(response *goyave.Response, r *goyave.Request) {
+			response.String(http.StatusOK, compressTestBody)
+		})
+	}, func() {
+		resp, err := suite.Get("/test", map[string]string{"Accept-Encoding": "br"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		etag := resp.Header.Get("ETag")
+		suite.NotEmpty(etag)
+		suite.Equal("br", resp.Header.Get("Content-Encoding"))
+		suite.Equal(compressTestBody, suite.decode(resp))
+
+		resp, err = suite.Get("/test", map[string]string{"Accept-Encoding": "br", "If-None-Match": etag})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		resp.Body.Close()
+		suite.Equal(http.StatusNotModified, resp.StatusCode)
+	})
+}
+
+func (suite *CompressMiddlewareTestSuite) TestCompressWeakensETag() {
+	suite.RunServer(func(router *goyave.Router) {
+		router.Middleware(Compress(nil))
+		router.Middleware(ETag())
+		router.Route("GET", "/test", func(response *goyave.Response, r *goyave.Request) {
+			response.String(http.StatusOK, compressTestBody)
+		})
+		router.Route("GET", "/weak", func(response *goyave.Response, r *goyave.Request) {
+			response.Header().Set("ETag", "W/\"weak\"")
+			response.String(http.StatusOK, compressTestBody)
+		})
+	}, func() {
+		resp, err := suite.Get("/test", map[string]string{"Accept-Encoding": "gzip"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		etag := resp.Header.Get("ETag")
+		suite.Equal("gzip", resp.Header.Get("Content-Encoding"))
+		suite.True(strings.HasPrefix(etag, "W/\""))
+		suite.Equal(compressTestBody, suite.decode(resp))
+
+		resp, err = suite.Get("/test", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		resp.Body.Close()
+		suite.Equal(http.StatusNotModified, resp.StatusCode)
+
+		resp, err = suite.Get("/test", map[string]string{"Accept-Encoding": "identity"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		suite.Empty(resp.Header.Get("Content-Encoding"))
+		suite.Equal(strings.TrimPrefix(etag, "W/"), resp.Header.Get("ETag"))
+		suite.Equal(compressTestBody, suite.decode(resp))
+
+		resp, err = suite.Get("/weak", map[string]string{"Accept-Encoding": "gzip"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		suite.Equal("W/\"weak\"", resp.Header.Get("ETag"))
+		suite.Equal(compressTestBody, suite.decode(resp))
+	})
+}
+
+func TestCompressMiddlewareSuite(t *testing.T) {
+	goyave.RunTest(t, new(CompressMiddlewareTestSuite))
+}
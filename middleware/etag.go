@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"goyave.dev/goyave/v3"
+)
+
+type etagWriter struct {
+	buffer      bytes.Buffer
+	childWriter io.Writer
+	response    *goyave.Response
+	request     *goyave.Request
+	weak        bool
+	released    bool
+}
+
+func (w *etagWriter) PreWrite(b []byte) {
+	if w.released {
+		if pr, ok := w.childWriter.(goyave.PreWriter); ok {
+			pr.PreWrite(b)
+		}
+	}
+}
+
+func (w *etagWriter) Write(b []byte) (int, error) {
+	if w.released {
+		return w.childWriter.Write(b)
+	}
+	return w.buffer.Write(b)
+}
+
+// Flush releases the buffered body without ETag, as flushing
+// means the response is streamed.
+func (w *etagWriter) Flush() {
+	if !w.released {
+		if err := w.release(w.response.GetStatus()); err != nil {
+			goyave.ErrLogger.Println(err)
+		}
+	}
+	if flusher, ok := w.childWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *etagWriter) Close() error {
+	var err error
+	if !w.released && !w.response.Hijacked() {
+		err = w.finish()
+	}
+
+	if wr, ok := w.childWriter.(io.Closer); ok {
+		if e := wr.Close(); e != nil {
+			return e
+		}
+	}
+	return err
+}
+
+// finish computes the ETag of the buffered body and responds with
+// "304 Not Modified" if it matches the "If-None-Match" request header.
+func (w *etagWriter) finish() error {
+	status := w.response.GetStatus()
+	if w.buffer.Len() == 0 || status < 200 || status >= 300 || w.response.IsHeaderWritten() {
+		return w.release(status)
+	}
+
+	header := w.response.Header()
+	etag := header.Get("ETag")
+	if etag == "" {
+		etag = generateETag(w.buffer.Bytes(), w.weak)
+		header.Set("ETag", etag)
+	}
+
+	if etagMatches(w.request.Header().Get("If-None-Match"), etag) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		w.buffer.Reset()
+		w.released = true
+		w.response.ReleaseHeader()
+		w.response.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+	return w.release(status)
+}
+
+// release writes the response header and the buffered
+// body, then lets further writes through.
+func (w *etagWriter) release(status int) error {
+	w.released = true
+	w.response.ReleaseHeader()
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+
+	b := w.buffer.Bytes()
+	w.PreWrite(b)
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.response.WriteHeader(status)
+	_, err := w.childWriter.Write(b)
+	w.buffer.Reset()
+	return err
+}
+
+func generateETag(body []byte, weak bool) string {
+	hash := sha1.Sum(body)
+	etag := "\"" + strconv.FormatInt(int64(len(body)), 16) + "-" + base64.RawURLEncoding.EncodeToString(hash[:]) + "\""
+	if weak {
+		return "W/" + etag
+	}
+	return etag
+}
+
+// etagMatches checks if the given "If-None-Match" header value
+// matches the given ETag, using the weak comparison function.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, value := range strings.Split(ifNoneMatch, ",") {
+		value = strings.TrimSpace(value)
+		if value == "*" || strings.TrimPrefix(value, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// ETag buffers the response body of "GET" and "HEAD" requests to compute a strong
+// ETag. If the "If-None-Match" request header matches, the response status is set
+// to "304 Not Modified" and the body is discarded.
+//
+// If the handler already sets the "ETag" header, it is not overridden but still used
+// to check "If-None-Match". Empty, hijacked, streamed (flushed) and non-2xx responses
+// are sent without ETag.
+func ETag() goyave.Middleware {
+	return etagMiddleware(false)
+}
+
+// WeakETag works like "ETag" but generates weak ETags ("W/" prefix), which don't
+// guarantee byte-for-byte equality. Use it for responses that are semantically
+// equivalent but may be encoded differently, for example if they are compressed.
+func WeakETag() goyave.Middleware {
+	return etagMiddleware(true)
+}
+
+func etagMiddleware(weak bool) goyave.Middleware {
+	return func(next goyave.Handler) goyave.Handler {
+		return func(response *goyave.Response, request *goyave.Request) {
+			method := request.Method()
+			if (method != http.MethodGet && method != http.MethodHead) || request.Header().Get("Upgrade") != "" {
+				next(response, request)
+				return
+			}
+
+			writer := &etagWriter{
+				childWriter: response.Writer(),
+				response:    response,
+				request:     request,
+				weak:        weak,
+			}
+			response.SetWriter(writer)
+			response.DeferHeader()
+
+			next(response, request)
+		}
+	}
+}
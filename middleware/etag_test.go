@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"goyave.dev/goyave/v3"
+)
+
+type ETagMiddlewareTestSuite struct {
+	goyave.TestSuite
+}
+
+func (suite *ETagMiddlewareTestSuite) readBody(resp *http.Response) string {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	return string(body)
+}
+
+func (suite *ETagMiddlewareTestSuite) TestGenerateETag() {
+	suite.Equal("\"b-Kq5sNclPz7QV2-lfQIuc6R7oRu0\"", generateETag([]byte("hello world"), false))
+	suite.Equal("W/\"b-Kq5sNclPz7QV2-lfQIuc6R7oRu0\"", generateETag([]byte("hello world"), true))
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMatches() {
+	suite.False(etagMatches("", "\"a\""))
+	suite.True(etagMatches("\"a\"", "\"a\""))
+	suite.True(etagMatches("\"b\", \"a\"", "\"a\""))
+	suite.True(etagMatches("W/\"a\"", "\"a\""))
+	suite.True(etagMatches("\"a\"", "W/\"a\""))
+	suite.True(etagMatches("*", "\"a\""))
+	suite.False(etagMatches("\"b\"", "\"a\""))
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMiddleware() {
+	handler := func(response *goyave.Response, r *goyave.Request) {
+		response.JSON(http.StatusOK, map[string]interface{}{"hello": "world"})
+	}
+	etag := generateETag([]byte("{\"hello\":\"world\"}\n"), false)
+
+	request := suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result := suite.Middleware(ETag(), request, handler)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal(etag, result.Header.Get("ETag"))
+	suite.Equal("application/json; charset=utf-8", result.Header.Get("Content-Type"))
+	suite.Equal("{\"hello\":\"world\"}\n", suite.readBody(result))
+
+	rawRequest := httptest.NewRequest("GET", "/", nil)
+	rawRequest.Header.Set("If-None-Match", etag)
+	request = suite.CreateTestRequest(rawRequest)
+	result = suite.Middleware(ETag(), request, handler)
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+	suite.Equal(etag, result.Header.Get("ETag"))
+	suite.Empty(result.Header.Get("Content-Type"))
+	suite.Empty(suite.readBody(result))
+
+	rawRequest = httptest.NewRequest("HEAD", "/", nil)
+	rawRequest.Header.Set("If-None-Match", "\"outdated\"")
+	request = suite.CreateTestRequest(rawRequest)
+	result = suite.Middleware(WeakETag(), request, handler)
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("W/"+etag, result.Header.Get("ETag"))
+
+	rawRequest = httptest.NewRequest("GET", "/", nil)
+	rawRequest.Header.Set("If-None-Match", etag)
+	request = suite.CreateTestRequest(rawRequest)
+	result = suite.Middleware(WeakETag(), request, handler)
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+	result.Body.Close()
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMiddlewareCustomETag() {
+	rawRequest := httptest.NewRequest("GET", "/", nil)
+	rawRequest.Header.Set("If-None-Match", "\"custom\"")
+	request := suite.CreateTestRequest(rawRequest)
+	result := suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.Header().Set("ETag", "\"custom\"")
+		response.String(http.StatusOK, "hello world")
+	})
+	suite.Equal(http.StatusNotModified, result.StatusCode)
+	suite.Equal("\"custom\"", result.Header.Get("ETag"))
+	result.Body.Close()
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMiddlewareSkip() {
+	// Non-2xx
+	request := suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result := suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusBadRequest, "error")
+	})
+	suite.Equal(http.StatusBadRequest, result.StatusCode)
+	suite.Empty(result.Header.Get("ETag"))
+	suite.Equal("error", suite.readBody(result))
+
+	// Empty
+	request = suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result = suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {})
+	suite.Equal(http.StatusNoContent, result.StatusCode)
+	suite.Empty(result.Header.Get("ETag"))
+	result.Body.Close()
+
+	// Status handler
+	request = suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result = suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.Status(http.StatusNotFound)
+	})
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+	suite.Empty(result.Header.Get("ETag"))
+	suite.Equal("{\"error\":\"Not Found\"}\n", suite.readBody(result))
+
+	// Method
+	request = suite.CreateTestRequest(httptest.NewRequest("POST", "/", nil))
+	result = suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusOK, "hello world")
+	})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Empty(result.Header.Get("ETag"))
+	suite.Equal("hello world", suite.readBody(result))
+
+	// Streaming
+	request = suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result = suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.String(http.StatusAccepted, "hello ")
+		response.Flush()
+		response.String(http.StatusAccepted, "world")
+	})
+	suite.Equal(http.StatusAccepted, result.StatusCode)
+	suite.Empty(result.Header.Get("ETag"))
+	suite.Equal("hello world", suite.readBody(result))
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMiddlewareWithGzip() {
+	suite.RunServer(func(router *goyave.Router) {
+		router.Middleware(ETag())
+		router.Middleware(Gzip())
+		router.Route("GET", "/test", func(response *goyave.Response, r *goyave.Request) {
+			response.String(http.StatusOK, "hello world")
+		})
+	}, func() {
+		resp, err := suite.Get("/test", map[string]string{"Accept-Encoding": "gzip"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		etag := resp.Header.Get("ETag")
+		suite.NotEmpty(etag)
+		reader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			panic(err)
+		}
+		body, err := ioutil.ReadAll(reader)
+		if err != nil {
+			panic(err)
+		}
+		resp.Body.Close()
+		suite.Equal("hello world", string(body))
+
+		resp, err = suite.Get("/test", map[string]string{"Accept-Encoding": "gzip", "If-None-Match": etag})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		resp.Body.Close()
+		suite.Equal(http.StatusNotModified, resp.StatusCode)
+	})
+}
+
+func (suite *ETagMiddlewareTestSuite) TestETagMiddlewareFile() {
+	request := suite.CreateTestRequest(httptest.NewRequest("GET", "/", nil))
+	result := suite.Middleware(ETag(), request, func(response *goyave.Response, r *goyave.Request) {
+		response.File("resources/test_file.txt")
+	})
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.NotEmpty(result.Header.Get("ETag"))
+	suite.NotEmpty(suite.readBody(result))
+}
+
+func TestETagMiddlewareSuite(t *testing.T) {
+	goyave.RunTest(t, new(ETagMiddlewareTestSuite))
+}
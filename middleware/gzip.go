@@ -14,25 +14,77 @@ type gzipWriter struct {
 	*gzip.Writer
 	http.ResponseWriter
 	childWriter io.Writer
+	decided     bool
+	passthrough bool
 }
 
 func (w *gzipWriter) PreWrite(b []byte) {
 	if pr, ok := w.childWriter.(goyave.PreWriter); ok {
 		pr.PreWrite(b)
 	}
+	if !w.decided {
+		w.decide(b)
+	}
+}
+
+// decide whether the response should be compressed. Responses already
+// having a "Content-Encoding", such as precompressed files, and partial
+// content are written as is.
+func (w *gzipWriter) decide(b []byte) {
+	w.decided = true
+	w.releaseHeader()
 	h := w.ResponseWriter.Header()
+	if w.status() == http.StatusPartialContent || h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		w.passthrough = true
+		return
+	}
+	h.Set("Content-Encoding", "gzip")
 	if h.Get("Content-Type") == "" {
 		h.Set("Content-Type", http.DetectContentType(b))
 	}
 	h.Del("Content-Length")
 }
 
+func (w *gzipWriter) releaseHeader() {
+	if response, ok := w.ResponseWriter.(*goyave.Response); ok {
+		response.ReleaseHeader()
+	}
+}
+
+func (w *gzipWriter) status() int {
+	if response, ok := w.ResponseWriter.(*goyave.Response); ok {
+		return response.GetStatus()
+	}
+	return 0
+}
+
 func (w *gzipWriter) Write(b []byte) (int, error) {
+	w.decided = true
+	if w.passthrough {
+		return w.childWriter.Write(b)
+	}
 	return w.Writer.Write(b)
 }
 
+// Flush writes the pending compressed data to the child writer
+// and flushes it if it implements http.Flusher.
+func (w *gzipWriter) Flush() {
+	if w.decided && !w.passthrough {
+		w.Writer.Flush()
+	}
+	if flusher, ok := w.childWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 func (w *gzipWriter) Close() error {
-	err := w.Writer.Close()
+	var err error
+	if !w.decided {
+		// Empty body, there is nothing to compress
+		w.releaseHeader()
+	} else if !w.passthrough {
+		err = w.Writer.Close()
+	}
 
 	if wr, ok := w.childWriter.(io.Closer); ok {
 		return wr.Close()
@@ -43,6 +95,10 @@ func (w *gzipWriter) Close() error {
 
 // Gzip compresses HTTP responses with default compression level
 // for clients that support it via the 'Accept-Encoding' header.
+//
+// Responses already having a "Content-Encoding" header, such as precompressed
+// files served with "StaticOptions.Precompressed", are not compressed again.
+// Partial content (byte ranges) is never compressed.
 func Gzip() goyave.Middleware {
 	return GzipLevel(gzip.DefaultCompression)
 }
@@ -63,8 +119,6 @@ func GzipLevel(level int) goyave.Middleware {
 				return
 			}
 
-			request.Header().Del("Accept-Encoding")
-
 			respWriter := response.Writer()
 			writer, _ := gzip.NewWriterLevel(respWriter, level)
 			compressWriter := &gzipWriter{
@@ -73,7 +127,9 @@ func GzipLevel(level int) goyave.Middleware {
 				childWriter:    respWriter,
 			}
 			response.SetWriter(compressWriter)
-			response.Header().Set("Content-Encoding", "gzip")
+			// Handlers such as "Response.File" may write the header before
+			// the body, let the writer decide the encoding first.
+			response.DeferHeader()
 
 			next(response, request)
 		}
@@ -1,12 +1,14 @@
 package middleware
 
 import (
+	"bytes"
 	"compress/gzip"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"testing/fstest"
 
 	"goyave.dev/goyave/v3"
 )
@@ -135,6 +137,32 @@ func (suite *GzipMiddlewareTestSuite) TestCloseChild() {
 	})
 }
 
+func (suite *GzipMiddlewareTestSuite) TestFlush() {
+	recorder := httptest.NewRecorder()
+	writer, _ := gzip.NewWriterLevel(recorder, gzip.BestCompression)
+	compressWriter := &gzipWriter{
+		Writer:         writer,
+		ResponseWriter: recorder,
+		childWriter:    recorder,
+	}
+	if _, err := compressWriter.Write([]byte("hello world")); err != nil {
+		panic(err)
+	}
+	length := recorder.Body.Len() // Only gzip header, content is buffered
+	compressWriter.Flush()
+	suite.True(recorder.Flushed)
+	suite.Greater(recorder.Body.Len(), length)
+
+	reader, err := gzip.NewReader(bytes.NewReader(recorder.Body.Bytes()))
+	if err != nil {
+		panic(err)
+	}
+	body := make([]byte, 11)
+	_, err = io.ReadFull(reader, body)
+	suite.Nil(err)
+	suite.Equal("hello world", string(body))
+}
+
 func (suite *GzipMiddlewareTestSuite) TestGzipMiddlewareInvalidLevel() {
 	suite.Panics(func() { GzipLevel(-3) })
 	suite.Panics(func() { GzipLevel(10) })
@@ -189,6 +217,73 @@ func (suite *GzipMiddlewareTestSuite) TestWriteFile() {
 	})
 }
 
+func (suite *GzipMiddlewareTestSuite) TestRange() {
+	suite.RunServer(func(router *goyave.Router) {
+		router.Middleware(Gzip())
+		router.Route("GET", "/test", func(response *goyave.Response, r *goyave.Request) {
+			response.File("resources/custom_config.json")
+		})
+	}, func() {
+		resp, err := suite.Get("/test", map[string]string{"Accept-Encoding": "gzip", "Range": "bytes=0-4"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		defer resp.Body.Close()
+		suite.Equal(http.StatusPartialContent, resp.StatusCode)
+		suite.Empty(resp.Header.Get("Content-Encoding"))
+		suite.Equal("bytes 0-4/31", resp.Header.Get("Content-Range"))
+		suite.Equal("5", resp.Header.Get("Content-Length"))
+		suite.Equal("{\n   ", string(suite.GetBody(resp)))
+	})
+}
+
+func (suite *GzipMiddlewareTestSuite) TestPrecompressed() {
+	var gz bytes.Buffer
+	writer := gzip.NewWriter(&gz)
+	if _, err := writer.Write([]byte("console.log('precompressed')")); err != nil {
+		panic(err)
+	}
+	writer.Close()
+	fsys := fstest.MapFS{
+		"app.js":    {Data: []byte("console.log('hello world')")},
+		"app.js.gz": {Data: gz.Bytes()},
+		"app.js.br": {Data: []byte("brotli")},
+	}
+
+	suite.RunServer(func(router *goyave.Router) {
+		router.Middleware(Gzip())
+		router.StaticWithOptions("/", fsys, &goyave.StaticOptions{Precompressed: true})
+	}, func() {
+		resp, err := suite.Get("/app.js", map[string]string{"Accept-Encoding": "gzip"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		defer resp.Body.Close()
+		suite.Equal("gzip", resp.Header.Get("Content-Encoding"))
+		reader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			panic(err)
+		}
+		body, err := ioutil.ReadAll(reader)
+		if err != nil {
+			panic(err)
+		}
+		suite.Equal("console.log('precompressed')", string(body)) // Not compressed twice
+
+		resp, err = suite.Get("/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		defer resp.Body.Close()
+		suite.Equal("br", resp.Header.Get("Content-Encoding"))
+		suite.Equal("6", resp.Header.Get("Content-Length"))
+		suite.Equal("brotli", string(suite.GetBody(resp)))
+	})
+}
+
 func TestGzipMiddlewareTestSuite(t *testing.T) {
 	goyave.RunTest(t, new(GzipMiddlewareTestSuite))
 }
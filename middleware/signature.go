@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"goyave.dev/goyave/v3"
+)
+
+// ValidateSignature rejects the requests whose URL has not been
+// signed or has expired with "403 Forbidden".
+// Use "Route.BuildSignedURL" to generate signed URLs.
+//
+//  router.Get("/unsubscribe/{userID}", user.Unsubscribe).Middleware(middleware.ValidateSignature)
+func ValidateSignature(next goyave.Handler) goyave.Handler {
+	return func(response *goyave.Response, request *goyave.Request) {
+		if !request.HasValidSignature() {
+			response.Status(http.StatusForbidden)
+			return
+		}
+		next(response, request)
+	}
+}
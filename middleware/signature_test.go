@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"goyave.dev/goyave/v3"
+	"goyave.dev/goyave/v3/config"
+)
+
+type SignatureMiddlewareTestSuite struct {
+	goyave.TestSuite
+}
+
+func (suite *SignatureMiddlewareTestSuite) SetupTest() {
+	config.Set("app.key", "secret")
+}
+
+func (suite *SignatureMiddlewareTestSuite) TearDownTest() {
+	config.Set("app.key", "")
+}
+
+func (suite *SignatureMiddlewareTestSuite) TestValidateSignature() {
+	router := goyave.NewRouter()
+	route := router.Get("/download/{file}", func(response *goyave.Response, r *goyave.Request) {})
+
+	request := suite.CreateTestRequest(httptest.NewRequest("GET", route.BuildSignedURI(time.Minute, "report.pdf"), nil))
+	result := suite.Middleware(ValidateSignature, request, func(response *goyave.Response, r *goyave.Request) {
+		response.Status(http.StatusOK)
+	})
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	request = suite.CreateTestRequest(httptest.NewRequest("GET", route.BuildSignedURI(-time.Minute, "report.pdf"), nil))
+	result = suite.Middleware(ValidateSignature, request, func(response *goyave.Response, r *goyave.Request) {
+		suite.Fail("ValidateSignature shouldn't pass.")
+	})
+	result.Body.Close()
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+
+	request = suite.CreateTestRequest(httptest.NewRequest("GET", "/download/report.pdf", nil))
+	result = suite.Middleware(ValidateSignature, request, func(response *goyave.Response, r *goyave.Request) {
+		suite.Fail("ValidateSignature shouldn't pass.")
+	})
+	result.Body.Close()
+	suite.Equal(http.StatusForbidden, result.StatusCode)
+}
+
+func TestSignatureMiddlewareTestSuite(t *testing.T) {
+	goyave.RunTest(t, new(SignatureMiddlewareTestSuite))
+}
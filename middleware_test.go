@@ -278,6 +278,136 @@ func (suite *MiddlewareTestSuite) TestParseJsonRequestMiddleware() {
 
 }
 
+func (suite *MiddlewareTestSuite) TestParseXMLRequestMiddleware() {
+	rawRequest := httptest.NewRequest("POST", "/test-route?query=param", strings.NewReader("<data><string>hello world</string><array>val1</array><array>val2</array></data>"))
+	rawRequest.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	executed := false
+	res := testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		suite.Equal("param", r.Data["query"])
+		suite.Equal("hello world", r.Data["string"])
+		suite.Equal([]interface{}{"val1", "val2"}, r.Data["array"])
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+
+	executed = false
+	rawRequest = httptest.NewRequest("POST", "/test-route", strings.NewReader("<data><string>hello world</data>"))
+	rawRequest.Header.Set("Content-Type", "text/xml")
+	res = testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		suite.Nil(r.Data)
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+
+	// Untyped decoders are validated like forms
+	executed = false
+	rawRequest = httptest.NewRequest("POST", "/test-route", strings.NewReader("<data><number>42</number></data>"))
+	rawRequest.Header.Set("Content-Type", "application/xml")
+	res = testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		r.Rules = validation.RuleSet{"number": {"required", "integer"}}.AsRules()
+		suite.Nil(r.validate())
+		suite.Equal(42, r.Data["number"])
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+}
+
+func (suite *MiddlewareTestSuite) TestParseMsgpackRequestMiddleware() {
+	body := []byte{0x82, 0xa6, 's', 't', 'r', 'i', 'n', 'g', 0xa5, 'h', 'e', 'l', 'l', 'o', 0xa6, 'n', 'u', 'm', 'b', 'e', 'r', 0x2a}
+	rawRequest := httptest.NewRequest("POST", "/test-route", bytes.NewReader(body))
+	rawRequest.Header.Set("Content-Type", "application/msgpack")
+	executed := false
+	res := testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		suite.Equal("hello", r.Data["string"])
+		suite.EqualValues(42, r.Data["number"])
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+}
+
+func (suite *MiddlewareTestSuite) TestParseCBORRequestMiddleware() {
+	body := []byte{0xa2, 0x66, 's', 't', 'r', 'i', 'n', 'g', 0x65, 'h', 'e', 'l', 'l', 'o', 0x66, 'n', 'u', 'm', 'b', 'e', 'r', 0x18, 0x2a}
+	rawRequest := httptest.NewRequest("POST", "/test-route", bytes.NewReader(body))
+	rawRequest.Header.Set("Content-Type", "application/cbor")
+	executed := false
+	res := testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		suite.Equal("hello", r.Data["string"])
+		suite.EqualValues(42, r.Data["number"])
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+}
+
+func (suite *MiddlewareTestSuite) TestParseUnsupportedMediaTypeMiddleware() {
+	rawRequest := httptest.NewRequest("POST", "/test-route", strings.NewReader("hello world"))
+	rawRequest.Header.Set("Content-Type", "text/plain")
+	request := createTestRequest(rawRequest)
+	response := newResponse(httptest.NewRecorder(), nil)
+	executed := false
+	parseRequestMiddleware(func(response *Response, r *Request) {
+		executed = true
+	})(response, request)
+	suite.False(executed)
+	suite.Equal(http.StatusUnsupportedMediaType, response.GetStatus())
+
+	// Empty body is accepted
+	rawRequest = httptest.NewRequest("POST", "/test-route?query=param", strings.NewReader(""))
+	rawRequest.Header.Set("Content-Type", "text/plain")
+	res := testMiddleware(parseRequestMiddleware, rawRequest, nil, validation.RuleSet{}, nil, func(response *Response, r *Request) {
+		suite.Equal(map[string]interface{}{"query": "param"}, r.Data)
+		executed = true
+	})
+	res.Body.Close()
+	suite.True(executed)
+
+	// Routes without validation rules receive the raw body
+	executed = false
+	rawRequest = httptest.NewRequest("POST", "/test-route?query=param", strings.NewReader("hello world"))
+	rawRequest.Header.Set("Content-Type", "text/plain")
+	request = createTestRequest(rawRequest)
+	request.Rules = nil
+	response = newResponse(httptest.NewRecorder(), nil)
+	parseRequestMiddleware(func(response *Response, r *Request) {
+		suite.Equal(map[string]interface{}{"query": "param"}, r.Data)
+		body, err := ioutil.ReadAll(r.Request().Body)
+		suite.Nil(err)
+		suite.Equal("hello world", string(body))
+		executed = true
+	})(response, request)
+	suite.True(executed)
+	suite.Equal(0, response.GetStatus())
+
+	// Without query
+	executed = false
+	rawRequest = httptest.NewRequest("POST", "/test-route", strings.NewReader("hello world"))
+	rawRequest.Header.Set("Content-Type", "application/octet-stream")
+	request = createTestRequest(rawRequest)
+	request.Rules = nil
+	parseRequestMiddleware(func(response *Response, r *Request) {
+		suite.NotNil(r.Data)
+		suite.Empty(r.Data)
+		executed = true
+	})(newResponse(httptest.NewRecorder(), nil), request)
+	suite.True(executed)
+
+	// Invalid query
+	executed = false
+	rawRequest = httptest.NewRequest("POST", "/test-route?%9", strings.NewReader("hello world"))
+	rawRequest.Header.Set("Content-Type", "application/octet-stream")
+	request = createTestRequest(rawRequest)
+	request.Rules = nil
+	parseRequestMiddleware(func(response *Response, r *Request) {
+		suite.Nil(r.Data)
+		executed = true
+	})(newResponse(httptest.NewRecorder(), nil), request)
+	suite.True(executed)
+}
+
 func (suite *MiddlewareTestSuite) TestParseMultipartRequestMiddleware() {
 	executed := false
 	rawRequest := createTestFileRequest("/test-route?test=hello", "resources/img/logo/goyave_16.png")
@@ -0,0 +1,406 @@
+package goyave
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"goyave.dev/goyave/v3/helper"
+)
+
+var (
+	// ErrNotTabular returned by the CSV encoder if the data cannot be
+	// represented as a table.
+	ErrNotTabular = errors.New("Data cannot be encoded as CSV: expected [][]string, a slice of maps or a slice of structs")
+)
+
+// Encoder writes response data in a specific format.
+// Encoders are used by "Response.Negotiate()" to respond
+// in the format preferred by the client.
+type Encoder struct {
+
+	// MediaType the media type produced by this encoder,
+	// "application/json" for example.
+	MediaType string
+
+	// Encode writes the given data to the response with the given status.
+	// It is expected to set the "Content-Type" header.
+	Encode func(response *Response, status int, data interface{}) error
+}
+
+var (
+	// JSONEncoder encodes the data as JSON. This is the same as using "Response.JSON()".
+	JSONEncoder = &Encoder{MediaType: "application/json", Encode: encodeJSON}
+
+	// XMLEncoder encodes the data as XML. Maps are converted to elements named after
+	// their keys, slices are converted to repeated elements. The root element is
+	// named "data". Other values are encoded using "encoding/xml".
+	XMLEncoder = &Encoder{MediaType: "application/xml", Encode: encodeXML}
+
+	// TextEncoder writes the data as plain text using its default format.
+	TextEncoder = &Encoder{MediaType: "text/plain", Encode: encodeText}
+
+	// CSVEncoder encodes tabular data as CSV. Supported data types are "[][]string",
+	// slices of maps and slices of structs. For maps and structs, a header row is
+	// written first. Map keys are sorted and struct fields are named after their
+	// "json" tag if present.
+	CSVEncoder = &Encoder{MediaType: "text/csv", Encode: encodeCSV}
+
+	encoders = []*Encoder{
+		JSONEncoder,
+		XMLEncoder,
+		TextEncoder,
+		CSVEncoder,
+	}
+	encodersMutex = &sync.RWMutex{}
+)
+
+// RegisterEncoder registers an encoder used by "Response.Negotiate()".
+// If an encoder is already registered for the same media type, it is replaced.
+// Otherwise the encoder is added with the lowest server preference, meaning it will
+// only be chosen for wildcard "Accept" values ("*/*") if no other encoder is registered.
+func RegisterEncoder(encoder *Encoder) {
+	encodersMutex.Lock()
+	defer encodersMutex.Unlock()
+	for i, e := range encoders {
+		if e.MediaType == encoder.MediaType {
+			encoders[i] = encoder
+			return
+		}
+	}
+	encoders = append(encoders, encoder)
+}
+
+// HTMLEncoder returns an encoder rendering the HTML template identified by the
+// given path. The template path is relative to the "resources/template" directory.
+//
+//  response.Negotiate(http.StatusOK, article, goyave.HTMLEncoder("article.html"))
+func HTMLEncoder(templatePath string) *Encoder {
+	return &Encoder{
+		MediaType: "text/html",
+		Encode: func(response *Response, status int, data interface{}) error {
+			if response.Header().Get("Content-Type") == "" {
+				response.Header().Set("Content-Type", "text/html; charset=utf-8")
+			}
+			return response.RenderHTML(status, templatePath, data)
+		},
+	}
+}
+
+// Negotiate writes the given data using the encoder matching the request's "Accept"
+// header the best, taking quality values into account. The given encoders are
+// considered before the registered ones ("JSONEncoder", "XMLEncoder", "TextEncoder"
+// and "CSVEncoder" by default) and take precedence over them for the same media type.
+// When the client accepts any media type, or if the "Accept" header is missing, the
+// first of the given encoders is used, or JSON if none is given.
+//
+// If no encoder matches, the status is set to "406 Not Acceptable" and nothing is
+// written, so the status handler can process the response.
+//
+//  response.Negotiate(http.StatusOK, articles, goyave.HTMLEncoder("articles.html"))
+func (r *Response) Negotiate(status int, data interface{}, encoders ...*Encoder) error {
+	r.Header().Add("Vary", "Accept")
+	accept := ""
+	if r.httpRequest != nil {
+		accept = r.httpRequest.Header.Get("Accept")
+	}
+
+	encoder := negotiateEncoder(accept, availableEncoders(encoders))
+	if encoder == nil {
+		r.Status(http.StatusNotAcceptable)
+		return nil
+	}
+	return encoder.Encode(r, status, data)
+}
+
+func availableEncoders(custom []*Encoder) []*Encoder {
+	encodersMutex.RLock()
+	defer encodersMutex.RUnlock()
+	available := make([]*Encoder, 0, len(custom)+len(encoders))
+	available = append(available, custom...)
+	for _, e := range encoders {
+		overridden := false
+		for _, c := range custom {
+			if c.MediaType == e.MediaType {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			available = append(available, e)
+		}
+	}
+	return available
+}
+
+// negotiateEncoder returns the encoder matching the given "Accept" header
+// value the best, or nil if none is acceptable.
+func negotiateEncoder(accept string, available []*Encoder) *Encoder {
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+	values := helper.ParseMultiValuesHeader(accept)
+
+	// Media types explicitly refused with "q=0"
+	refused := make([]string, 0, 1)
+	for _, v := range values {
+		if v.Priority == 0 {
+			refused = append(refused, strings.ToLower(v.Value))
+		}
+	}
+
+	for _, v := range values {
+		if v.Priority == 0 {
+			break // Values are sorted by priority
+		}
+		accepted := strings.ToLower(v.Value)
+		for _, e := range available {
+			if matchMediaType(accepted, e.MediaType) && !isRefused(e.MediaType, refused) {
+				return e
+			}
+		}
+	}
+	return nil
+}
+
+func isRefused(mediaType string, refused []string) bool {
+	for _, r := range refused {
+		if r == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// matchMediaType returns true if the given "Accept" value
+// ("text/html", "text/*" or "*/*") matches the given media type.
+func matchMediaType(accepted, mediaType string) bool {
+	if accepted == "*/*" || accepted == "*" || accepted == mediaType {
+		return true
+	}
+	if strings.HasSuffix(accepted, "/*") {
+		return strings.HasPrefix(mediaType, accepted[:len(accepted)-1])
+	}
+	return false
+}
+
+func encodeJSON(response *Response, status int, data interface{}) error {
+	return response.JSON(status, data)
+}
+
+func encodeText(response *Response, status int, data interface{}) error {
+	response.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	return response.String(status, helper.ToString(data))
+}
+
+func encodeXML(response *Response, status int, data interface{}) error {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&b)
+	var err error
+	switch data.(type) {
+	case map[string]interface{}, []interface{}:
+		if err = encodeXMLValue(encoder, "data", data); err == nil {
+			err = encoder.Flush()
+		}
+	default:
+		err = encoder.Encode(data)
+	}
+	if err != nil {
+		return err
+	}
+
+	response.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	response.status = status
+	_, err = response.Write(b.Bytes())
+	return err
+}
+
+func encodeXMLValue(encoder *xml.Encoder, name string, value interface{}) error {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if err := encodeXMLField(encoder, k, v[k]); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case []interface{}:
+		if err := encoder.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, item := range v {
+			if err := encodeXMLValue(encoder, "item", item); err != nil {
+				return err
+			}
+		}
+		return encoder.EncodeToken(start.End())
+	case nil:
+		return encoder.EncodeElement("", start)
+	}
+	return encoder.EncodeElement(value, start)
+}
+
+// encodeXMLField encodes a map entry. Slices are encoded as repeated
+// elements so they can be decoded back by the XML body decoder.
+func encodeXMLField(encoder *xml.Encoder, name string, value interface{}) error {
+	if slice, ok := value.([]interface{}); ok {
+		for _, item := range slice {
+			if err := encodeXMLValue(encoder, name, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return encodeXMLValue(encoder, name, value)
+}
+
+func encodeCSV(response *Response, status int, data interface{}) error {
+	records, err := csvRecords(data)
+	if err != nil {
+		return err
+	}
+	response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	response.status = status
+	writer := csv.NewWriter(response)
+	return writer.WriteAll(records)
+}
+
+func csvRecords(data interface{}) ([][]string, error) {
+	if records, ok := data.([][]string); ok {
+		return records, nil
+	}
+
+	value := reflect.ValueOf(data)
+	if value.Kind() != reflect.Slice {
+		return nil, ErrNotTabular
+	}
+	elemType := indirectElemType(value.Type().Elem())
+	switch elemType.Kind() {
+	case reflect.Map:
+		if elemType.Key().Kind() != reflect.String {
+			return nil, ErrNotTabular
+		}
+		return csvRecordsFromMaps(value), nil
+	case reflect.Struct:
+		return csvRecordsFromStructs(value, elemType), nil
+	case reflect.Interface:
+		if value.Len() == 0 {
+			return [][]string{}, nil
+		}
+		return csvRecordsFromInterfaces(value)
+	}
+	return nil, ErrNotTabular
+}
+
+func indirectElemType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+func csvRecordsFromInterfaces(value reflect.Value) ([][]string, error) {
+	maps := make([]map[string]interface{}, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		m, ok := value.Index(i).Interface().(map[string]interface{})
+		if !ok {
+			return nil, ErrNotTabular
+		}
+		maps = append(maps, m)
+	}
+	return csvRecordsFromMaps(reflect.ValueOf(maps)), nil
+}
+
+func csvRecordsFromMaps(value reflect.Value) [][]string {
+	keySet := make(map[string]bool, 10)
+	for i := 0; i < value.Len(); i++ {
+		for _, k := range reflect.Indirect(value.Index(i)).MapKeys() {
+			keySet[k.String()] = true
+		}
+	}
+	header := make([]string, 0, len(keySet))
+	for k := range keySet {
+		header = append(header, k)
+	}
+	sort.Strings(header)
+
+	records := make([][]string, 0, value.Len()+1)
+	records = append(records, header)
+	for i := 0; i < value.Len(); i++ {
+		m := reflect.Indirect(value.Index(i))
+		record := make([]string, 0, len(header))
+		for _, k := range header {
+			v := m.MapIndex(reflect.ValueOf(k).Convert(m.Type().Key()))
+			record = append(record, csvValue(v))
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func csvRecordsFromStructs(value reflect.Value, structType reflect.Type) [][]string {
+	header := make([]string, 0, structType.NumField())
+	fields := make([]int, 0, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" { // Unexported
+			continue
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			if tag == "-" {
+				continue
+			}
+			name = tag
+		}
+		header = append(header, name)
+		fields = append(fields, i)
+	}
+
+	records := make([][]string, 0, value.Len()+1)
+	records = append(records, header)
+	for i := 0; i < value.Len(); i++ {
+		s := reflect.Indirect(value.Index(i))
+		record := make([]string, 0, len(fields))
+		for _, f := range fields {
+			if s.IsValid() {
+				record = append(record, csvValue(s.Field(f)))
+			} else {
+				record = append(record, "")
+			}
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func csvValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return ""
+	}
+	if (value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr) && value.IsNil() {
+		return ""
+	}
+	v := value.Interface()
+	if stringer, ok := v.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+	return helper.ToString(reflect.Indirect(reflect.ValueOf(v)).Interface())
+}
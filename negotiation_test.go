@@ -0,0 +1,189 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type NegotiationTestSuite struct {
+	TestSuite
+}
+
+type negotiationTestModel struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Hidden string `json:"-"`
+	secret string
+}
+
+func (suite *NegotiationTestSuite) negotiate(accept string, status int, data interface{}, encoders ...*Encoder) (*Response, *http.Response, string, error) {
+	rawRequest := httptest.NewRequest("GET", "/test-route", nil)
+	if accept != "" {
+		rawRequest.Header.Set("Accept", accept)
+	}
+	response := newResponse(httptest.NewRecorder(), rawRequest)
+	err := response.Negotiate(status, data, encoders...)
+	resp := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if readErr != nil {
+		panic(readErr)
+	}
+	return response, resp, string(body), err
+}
+
+func (suite *NegotiationTestSuite) TestNegotiateEncoder() {
+	html := HTMLEncoder("error.html")
+	available := availableEncoders([]*Encoder{html})
+	suite.Same(html, negotiateEncoder("", available))
+	suite.Same(html, negotiateEncoder("*/*", available))
+	suite.Same(JSONEncoder, negotiateEncoder("application/json", available))
+	suite.Same(XMLEncoder, negotiateEncoder("text/html;q=0.5, application/xml", available))
+	suite.Same(html, negotiateEncoder("text/*", available))
+	suite.Same(TextEncoder, negotiateEncoder("text/*, text/html;q=0", available))
+	suite.Same(CSVEncoder, negotiateEncoder("TEXT/CSV", available))
+	suite.Same(JSONEncoder, negotiateEncoder("*/*, text/html;q=0, application/xml;q=0", available))
+	suite.Nil(negotiateEncoder("image/png", available))
+	suite.Nil(negotiateEncoder("application/json;q=0", available))
+	suite.Same(JSONEncoder, negotiateEncoder("application/json; q=0.9", available))
+	suite.Same(JSONEncoder, negotiateEncoder("application/json;q=1, text/html;q=0.5", available))
+	suite.Same(html, negotiateEncoder("text/html;level=1", available))
+	suite.Same(html, negotiateEncoder("application/json; q=0, text/html; level=1; q=0.8", available))
+	suite.Same(html, negotiateEncoder("application/json;q=invalid, text/html", available))
+
+	available = availableEncoders(nil)
+	suite.Same(JSONEncoder, negotiateEncoder("", available))
+	suite.Nil(negotiateEncoder("text/html", available))
+}
+
+func (suite *NegotiationTestSuite) TestAvailableEncoders() {
+	custom := &Encoder{MediaType: "application/json"}
+	available := availableEncoders([]*Encoder{custom})
+	suite.Len(available, 4)
+	suite.Same(custom, available[0])
+	suite.Same(XMLEncoder, available[1])
+}
+
+func (suite *NegotiationTestSuite) TestRegisterEncoder() {
+	yaml := &Encoder{MediaType: "application/yaml"}
+	RegisterEncoder(yaml)
+	defer func() {
+		encodersMutex.Lock()
+		encoders = encoders[:len(encoders)-1]
+		encodersMutex.Unlock()
+	}()
+	suite.Same(yaml, encoders[len(encoders)-1])
+	suite.Same(yaml, negotiateEncoder("application/yaml", availableEncoders(nil)))
+	suite.Same(JSONEncoder, negotiateEncoder("*/*", availableEncoders(nil)))
+
+	prev := JSONEncoder
+	json := &Encoder{MediaType: "application/json"}
+	RegisterEncoder(json)
+	suite.Same(json, encoders[0])
+	RegisterEncoder(prev)
+	suite.Same(prev, encoders[0])
+}
+
+func (suite *NegotiationTestSuite) TestNegotiate() {
+	data := map[string]interface{}{"name": "goyave"}
+
+	response, resp, body, err := suite.negotiate("", http.StatusCreated, data)
+	suite.Nil(err)
+	suite.Equal(http.StatusCreated, resp.StatusCode)
+	suite.Equal("application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Equal("Accept", resp.Header.Get("Vary"))
+	suite.Equal("{\"name\":\"goyave\"}\n", body)
+	suite.False(response.IsEmpty())
+
+	_, resp, body, err = suite.negotiate("text/plain", http.StatusOK, "hello")
+	suite.Nil(err)
+	suite.Equal("text/plain; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Equal("hello", body)
+
+	_, resp, body, err = suite.negotiate("text/html", http.StatusOK, map[string]interface{}{"Status": "404", "Message": "Not found"}, HTMLEncoder("error.html"))
+	suite.Nil(err)
+	suite.Equal("text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Contains(body, "Not found")
+
+	response, resp, body, err = suite.negotiate("image/png", http.StatusOK, data)
+	suite.Nil(err)
+	suite.Equal(http.StatusNotAcceptable, response.GetStatus())
+	suite.True(response.IsEmpty())
+	suite.Empty(body)
+	suite.Equal("Accept", resp.Header.Get("Vary"))
+}
+
+func (suite *NegotiationTestSuite) TestNegotiateXML() {
+	data := map[string]interface{}{
+		"name": "goyave",
+		"tags": []interface{}{"a", "b"},
+		"meta": map[string]interface{}{"version": 3, "empty": nil},
+	}
+	_, resp, body, err := suite.negotiate("application/xml", http.StatusOK, data)
+	suite.Nil(err)
+	suite.Equal("application/xml; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<data><meta><empty></empty><version>3</version></meta><name>goyave</name><tags>a</tags><tags>b</tags></data>", body)
+
+	decoded, err := decodeXML([]byte(body))
+	suite.Nil(err)
+	suite.Equal("goyave", decoded["name"])
+	suite.Equal([]interface{}{"a", "b"}, decoded["tags"])
+
+	_, _, body, err = suite.negotiate("application/xml", http.StatusOK, []interface{}{"a", map[string]interface{}{"b": "c"}})
+	suite.Nil(err)
+	suite.Equal("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<data><item>a</item><item><b>c</b></item></data>", body)
+
+	_, _, body, err = suite.negotiate("application/xml", http.StatusOK, &negotiationTestModel{ID: 1, Name: "goyave"})
+	suite.Nil(err)
+	suite.Contains(body, "<negotiationTestModel><ID>1</ID><Name>goyave</Name>")
+
+	response, _, body, err := suite.negotiate("application/xml", http.StatusOK, map[string]interface{}{"invalid": make(chan int)})
+	suite.NotNil(err)
+	suite.Empty(body)
+	suite.True(response.IsEmpty())
+}
+
+func (suite *NegotiationTestSuite) TestNegotiateCSV() {
+	_, resp, body, err := suite.negotiate("text/csv", http.StatusOK, [][]string{{"a", "b"}, {"1", "2"}})
+	suite.Nil(err)
+	suite.Equal("text/csv; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Equal("a,b\n1,2\n", body)
+
+	_, _, body, err = suite.negotiate("text/csv", http.StatusOK, []map[string]interface{}{
+		{"name": "a", "id": 1},
+		{"name": "b, c", "extra": nil},
+	})
+	suite.Nil(err)
+	suite.Equal("extra,id,name\n,1,a\n,,\"b, c\"\n", body)
+
+	_, _, body, err = suite.negotiate("text/csv", http.StatusOK, []interface{}{
+		map[string]interface{}{"id": 1},
+	})
+	suite.Nil(err)
+	suite.Equal("id\n1\n", body)
+
+	_, _, body, err = suite.negotiate("text/csv", http.StatusOK, []*negotiationTestModel{
+		{ID: 1, Name: "a", Hidden: "h", secret: "s"},
+		nil,
+	})
+	suite.Nil(err)
+	suite.Equal("id,name\n1,a\n,\n", body)
+
+	response, _, body, err := suite.negotiate("text/csv", http.StatusOK, map[string]interface{}{"id": 1})
+	suite.Equal(ErrNotTabular, err)
+	suite.Empty(body)
+	suite.True(response.IsEmpty())
+
+	_, _, _, err = suite.negotiate("text/csv", http.StatusOK, []interface{}{"a"})
+	suite.Equal(ErrNotTabular, err)
+	_, _, _, err = suite.negotiate("text/csv", http.StatusOK, []map[int]string{{1: "a"}})
+	suite.Equal(ErrNotTabular, err)
+	_, _, _, err = suite.negotiate("text/csv", http.StatusOK, []string{"a"})
+	suite.Equal(ErrNotTabular, err)
+}
+
+func TestNegotiationTestSuite(t *testing.T) {
+	RunTest(t, new(NegotiationTestSuite))
+}
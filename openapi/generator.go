@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"goyave.dev/goyave/v3"
+)
+
+// Generate creates an OpenAPI 3 specification describing all the routes
+// registered in the given router and its subrouters.
+//
+// Paths and their parameters are generated from the full URI of the routes.
+// Parameters constrained by a pattern ("{id:[0-9]+}") are described with this
+// pattern. Request bodies (or query parameters for "GET" and "DELETE" routes)
+// are generated from the routes' validation rules.
+//
+// Operation IDs are the names of the routes. If a route has several methods,
+// the lowercase method is appended to make them unique (e.g. "product.update.patch").
+//
+// The "HEAD" and "OPTIONS" methods are omitted as they are usually
+// handled automatically.
+func Generate(router *goyave.Router, info *Info) *Spec {
+	spec := &Spec{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+	}
+	generateRouter(spec, router)
+	return spec
+}
+
+func generateRouter(spec *Spec, router *goyave.Router) {
+	for _, route := range router.GetRoutes() {
+		generateRoute(spec, route)
+	}
+	for _, subrouter := range router.GetSubrouters() {
+		generateRouter(spec, subrouter)
+	}
+}
+
+// documentedMethods returns the methods of the given route,
+// excluding "HEAD" and "OPTIONS".
+func documentedMethods(route *goyave.Route) []string {
+	methods := make([]string, 0, len(route.GetMethods()))
+	for _, method := range route.GetMethods() {
+		if method != http.MethodHead && method != http.MethodOptions {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+func generateRoute(spec *Spec, route *goyave.Route) {
+	path, parameters := convertPath(route.GetFullURI())
+	item, ok := spec.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		spec.Paths[path] = item
+	}
+
+	methods := documentedMethods(route)
+	for _, method := range methods {
+		operationID := route.GetName()
+		if operationID != "" && len(methods) > 1 {
+			// Operation IDs must be unique
+			operationID += "." + strings.ToLower(method)
+		}
+
+		op := &Operation{
+			OperationID: operationID,
+			Parameters:  append([]*Parameter{}, parameters...),
+			Responses: map[string]*Response{
+				"default": {Description: "Default response"},
+			},
+		}
+
+		if rules := route.GetValidationRules(); rules != nil {
+			if method == http.MethodGet || method == http.MethodDelete {
+				op.Parameters = append(op.Parameters, queryParameters(rules)...)
+				op.Responses["400"] = &Response{Description: "Validation error"}
+			} else {
+				op.RequestBody = requestBody(rules)
+				op.Responses["422"] = &Response{Description: "Validation error"}
+			}
+		}
+
+		setOperation(item, method, op)
+	}
+}
+
+func setOperation(item *PathItem, method string, op *Operation) {
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodDelete:
+		item.Delete = op
+	case http.MethodPatch:
+		item.Patch = op
+	case http.MethodTrace:
+		item.Trace = op
+	}
+}
+
+// convertPath converts a goyave URI to an OpenAPI path by removing the
+// parameters' patterns. The path parameters are returned as well,
+// in order of appearance.
+//
+// Given "/product/{id:[0-9]+}/{name}"
+// The returned path will be "/product/{id}/{name}"
+func convertPath(uri string) (string, []*Parameter) {
+	var builder strings.Builder
+	parameters := []*Parameter{}
+	level := 0
+	start := 0
+	for i := 0; i < len(uri); i++ {
+		switch uri[i] {
+		case '{':
+			level++
+			if level == 1 {
+				start = i + 1
+			}
+		case '}':
+			level--
+			if level == 0 {
+				parameter := &Parameter{
+					In:       "path",
+					Required: true,
+					Schema:   &Schema{Type: "string"},
+				}
+				parts := strings.SplitN(uri[start:i], ":", 2)
+				parameter.Name = parts[0]
+				if len(parts) == 2 {
+					parameter.Schema.Pattern = "^(?:" + parts[1] + ")$"
+				}
+				parameters = append(parameters, parameter)
+				builder.WriteString("{" + parameter.Name + "}")
+			}
+		default:
+			if level == 0 {
+				builder.WriteByte(uri[i])
+			}
+		}
+	}
+
+	path := builder.String()
+	if path == "" {
+		path = "/"
+	}
+	return path, parameters
+}
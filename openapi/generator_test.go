@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"goyave.dev/goyave/v3"
+	"goyave.dev/goyave/v3/validation"
+)
+
+type OpenAPITestSuite struct {
+	goyave.TestSuite
+}
+
+func (suite *OpenAPITestSuite) TestConvertPath() {
+	path, params := convertPath("/product/{id:[0-9]+}/{name}")
+	suite.Equal("/product/{id}/{name}", path)
+	suite.Len(params, 2)
+	suite.Equal("id", params[0].Name)
+	suite.Equal("path", params[0].In)
+	suite.True(params[0].Required)
+	suite.Equal("^(?:[0-9]+)$", params[0].Schema.Pattern)
+	suite.Equal("name", params[1].Name)
+	suite.Empty(params[1].Schema.Pattern)
+
+	path, params = convertPath("/categories/{sort:(?:a{1,2}|desc)}")
+	suite.Equal("/categories/{sort}", path)
+	suite.Len(params, 1)
+	suite.Equal("^(?:(?:a{1,2}|desc))$", params[0].Schema.Pattern)
+
+	path, params = convertPath("")
+	suite.Equal("/", path)
+	suite.Empty(params)
+}
+
+func (suite *OpenAPITestSuite) TestGenerate() {
+	handler := func(response *goyave.Response, request *goyave.Request) {}
+	router := goyave.NewRouter()
+	router.Get("/hello", handler).Name("hello").Validate(validation.RuleSet{
+		"page": {"integer", "min:1"},
+	})
+
+	productRouter := router.Subrouter("/product")
+	productRouter.Get("/{id:[0-9]+}", handler).Name("product.show")
+	productRouter.Route("PUT|PATCH", "/{id:[0-9]+}", handler).Name("product.update").Validate(validation.RuleSet{
+		"name":  {"required", "string", "max:255"},
+		"price": {"numeric", "min:0"},
+	})
+	productRouter.Post("/", handler).Validate(validation.RuleSet{
+		"image": {"required", "file", "image"},
+	})
+
+	spec := Generate(router, &Info{Title: "Test API", Version: "1.0.0"})
+	suite.Equal(Version, spec.OpenAPI)
+	suite.Equal("Test API", spec.Info.Title)
+	suite.Len(spec.Paths, 3)
+
+	hello := spec.Paths["/hello"]
+	suite.NotNil(hello.Get)
+	suite.Nil(hello.Head)
+	suite.Equal("hello", hello.Get.OperationID)
+	suite.Nil(hello.Get.RequestBody)
+	suite.Len(hello.Get.Parameters, 1)
+	suite.Equal("page", hello.Get.Parameters[0].Name)
+	suite.Equal("query", hello.Get.Parameters[0].In)
+	suite.False(hello.Get.Parameters[0].Required)
+	suite.Equal("integer", hello.Get.Parameters[0].Schema.Type)
+	suite.Equal(1.0, *hello.Get.Parameters[0].Schema.Minimum)
+	suite.Contains(hello.Get.Responses, "400")
+
+	product := spec.Paths["/product/{id}"]
+	suite.NotNil(product.Get)
+	suite.Equal("product.show", product.Get.OperationID)
+	suite.Len(product.Get.Parameters, 1)
+	suite.Equal("id", product.Get.Parameters[0].Name)
+	suite.NotNil(product.Put)
+	suite.NotNil(product.Patch)
+	suite.Equal("product.update.put", product.Put.OperationID)
+	suite.Equal("product.update.patch", product.Patch.OperationID)
+	suite.Len(product.Put.Parameters, 1)
+	suite.NotNil(product.Put.RequestBody)
+	suite.True(product.Put.RequestBody.Required)
+	schema := product.Put.RequestBody.Content["application/json"].Schema
+	suite.Equal("object", schema.Type)
+	suite.Equal([]string{"name"}, schema.Required)
+	suite.Equal("string", schema.Properties["name"].Type)
+	suite.Equal(255, *schema.Properties["name"].MaxLength)
+	suite.Equal("number", schema.Properties["price"].Type)
+	suite.Contains(product.Put.Responses, "422")
+
+	store := spec.Paths["/product"]
+	suite.NotNil(store.Post)
+	suite.Empty(store.Post.OperationID)
+	schema = store.Post.RequestBody.Content["multipart/form-data"].Schema
+	suite.Equal("string", schema.Properties["image"].Type)
+	suite.Equal("binary", schema.Properties["image"].Format)
+}
+
+func (suite *OpenAPITestSuite) TestHandler() {
+	handler := func(response *goyave.Response, request *goyave.Request) {}
+	router := goyave.NewRouter()
+	specHandler := Handler(router, &Info{Title: "Test API", Version: "1.0.0"})
+	router.Get("/openapi", specHandler)
+	router.Post("/product", handler) // Registered after the handler
+
+	request := suite.CreateTestRequest(httptest.NewRequest(http.MethodGet, "/openapi", nil))
+	result := suite.Middleware(func(next goyave.Handler) goyave.Handler { return next }, request, specHandler)
+	body := suite.GetBody(result)
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("application/json; charset=utf-8", result.Header.Get("Content-Type"))
+
+	spec := &Spec{}
+	suite.Nil(json.Unmarshal(body, spec))
+	suite.Equal("Test API", spec.Info.Title)
+	suite.Contains(spec.Paths, "/openapi")
+	suite.Contains(spec.Paths, "/product")
+
+	rawRequest := httptest.NewRequest(http.MethodGet, "/openapi", nil)
+	rawRequest.Header.Set("Accept", "application/yaml")
+	request = suite.CreateTestRequest(rawRequest)
+	result = suite.Middleware(func(next goyave.Handler) goyave.Handler { return next }, request, specHandler)
+	body = suite.GetBody(result)
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("application/yaml; charset=utf-8", result.Header.Get("Content-Type"))
+	suite.Contains(string(body), "openapi: 3.0.3\n")
+}
+
+func TestOpenAPITestSuite(t *testing.T) {
+	goyave.RunTest(t, new(OpenAPITestSuite))
+}
@@ -0,0 +1,45 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"goyave.dev/goyave/v3"
+)
+
+// Handler returns a handler serving the OpenAPI specification of the given router.
+//
+// The specification is generated on the first request, so routes registered after
+// this handler are included. It is served as JSON, or as YAML if the request's
+// "Accept" header contains "yaml".
+//
+//  router.Get("/openapi.json", openapi.Handler(router, &openapi.Info{Title: "My API", Version: "1.0.0"}))
+func Handler(router *goyave.Router, info *Info) goyave.Handler {
+	var once sync.Once
+	var jsonSpec, yamlSpec []byte
+	var err error
+	return func(response *goyave.Response, request *goyave.Request) {
+		once.Do(func() {
+			spec := Generate(router, info)
+			if jsonSpec, err = spec.JSON(); err != nil {
+				return
+			}
+			yamlSpec, err = spec.YAML()
+		})
+
+		if err != nil {
+			response.Error(err)
+			return
+		}
+
+		if strings.Contains(request.Header().Get("Accept"), "yaml") {
+			response.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+			response.String(http.StatusOK, string(yamlSpec))
+			return
+		}
+
+		response.Header().Set("Content-Type", "application/json; charset=utf-8")
+		response.String(http.StatusOK, string(jsonSpec))
+	}
+}
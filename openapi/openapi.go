@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"encoding/json"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version the version of the OpenAPI specification generated by this package.
+const Version = "3.0.3"
+
+// Spec is the root object of an OpenAPI document.
+type Spec struct {
+	OpenAPI string               `json:"openapi" yaml:"openapi"`
+	Info    *Info                `json:"info" yaml:"info"`
+	Servers []*Server            `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths   map[string]*PathItem `json:"paths" yaml:"paths"`
+}
+
+// Info provides metadata about the API.
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// Server represents a server hosting the API.
+type Server struct {
+	URL         string `json:"url" yaml:"url"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// PathItem describes the operations available on a single path.
+type PathItem struct {
+	Get     *Operation `json:"get,omitempty" yaml:"get,omitempty"`
+	Put     *Operation `json:"put,omitempty" yaml:"put,omitempty"`
+	Post    *Operation `json:"post,omitempty" yaml:"post,omitempty"`
+	Delete  *Operation `json:"delete,omitempty" yaml:"delete,omitempty"`
+	Options *Operation `json:"options,omitempty" yaml:"options,omitempty"`
+	Head    *Operation `json:"head,omitempty" yaml:"head,omitempty"`
+	Patch   *Operation `json:"patch,omitempty" yaml:"patch,omitempty"`
+	Trace   *Operation `json:"trace,omitempty" yaml:"trace,omitempty"`
+}
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses" yaml:"responses"`
+}
+
+// Parameter describes a single operation parameter, located
+// either in the path or in the query.
+type Parameter struct {
+	Name     string  `json:"name" yaml:"name"`
+	In       string  `json:"in" yaml:"in"`
+	Required bool    `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// RequestBody describes a single request body.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty" yaml:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content" yaml:"content"`
+}
+
+// MediaType provides the schema of a request or response body
+// for a given content type.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
+}
+
+// Response describes a single response from an API operation.
+type Response struct {
+	Description string                `json:"description" yaml:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+}
+
+// Schema defines an input or output data type.
+type Schema struct {
+	Type        string             `json:"type,omitempty" yaml:"type,omitempty"`
+	Format      string             `json:"format,omitempty" yaml:"format,omitempty"`
+	Pattern     string             `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	Nullable    bool               `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems    *int               `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems    *int               `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
+	UniqueItems bool               `json:"uniqueItems,omitempty" yaml:"uniqueItems,omitempty"`
+	Items       *Schema            `json:"items,omitempty" yaml:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// JSON returns the JSON representation of the specification.
+func (s *Spec) JSON() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// YAML returns the YAML representation of the specification.
+func (s *Spec) YAML() ([]byte, error) {
+	return yaml.Marshal(s)
+}
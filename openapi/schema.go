@@ -0,0 +1,275 @@
+package openapi
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goyave.dev/goyave/v3/validation"
+)
+
+// requestBody generates the request body description from the given rules.
+// If at least one field is a file, the body is described as "multipart/form-data",
+// otherwise as "application/json".
+func requestBody(rules *validation.Rules) *RequestBody {
+	schema := ObjectSchema(rules)
+	contentType := "application/json"
+	for _, field := range rules.Fields {
+		if hasRule(field, "file") {
+			contentType = "multipart/form-data"
+			break
+		}
+	}
+	return &RequestBody{
+		Required: len(schema.Required) > 0,
+		Content: map[string]*MediaType{
+			contentType: {Schema: schema},
+		},
+	}
+}
+
+// queryParameters generates query parameters from the given rules.
+// Nested fields are ignored.
+func queryParameters(rules *validation.Rules) []*Parameter {
+	names := make([]string, 0, len(rules.Fields))
+	for name := range rules.Fields {
+		if !strings.Contains(name, ".") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	parameters := make([]*Parameter, 0, len(names))
+	for _, name := range names {
+		field := rules.Fields[name]
+		parameters = append(parameters, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: field.IsRequired(),
+			Schema:   FieldSchema(field),
+		})
+	}
+	return parameters
+}
+
+// ObjectSchema generates an object schema from the given rules.
+// Dot-separated field names ("user.name") are converted to
+// nested object properties and wildcard field names ("items[].quantity")
+// to arrays of objects.
+func ObjectSchema(rules *validation.Rules) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+
+	names := make([]string, 0, len(rules.Fields))
+	for name := range rules.Fields {
+		names = append(names, name)
+	}
+	// Sorting ensures parents are processed before their children
+	// and makes the output deterministic.
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := rules.Fields[name]
+		parent := schema
+		segments := strings.Split(name, ".")
+		for _, segment := range segments[:len(segments)-1] {
+			isArray := strings.HasSuffix(segment, "[]")
+			segment = strings.TrimSuffix(segment, "[]")
+			child, ok := parent.Properties[segment]
+			if !ok {
+				child = &Schema{Type: "object"}
+				parent.Properties[segment] = child
+			}
+			if isArray {
+				child = schemaAt(child, 1)
+				if child.Type == "" {
+					child.Type = "object"
+				}
+			}
+			if child.Properties == nil {
+				child.Properties = map[string]*Schema{}
+			}
+			parent = child
+		}
+
+		key := segments[len(segments)-1]
+		parent.Properties[key] = FieldSchema(field)
+		if field.IsRequired() {
+			parent.Required = append(parent.Required, key)
+		}
+	}
+
+	return schema
+}
+
+// FieldSchema generates the schema of a single field from its rules.
+func FieldSchema(field *validation.Field) *Schema {
+	schema := &Schema{}
+
+	// Types are applied first so type-dependent rules
+	// know the type of the field.
+	for _, rule := range field.Rules {
+		applyTypeRule(schemaAt(schema, rule.ArrayDimension), rule)
+	}
+	for _, rule := range field.Rules {
+		applyRule(schemaAt(schema, rule.ArrayDimension), rule)
+	}
+
+	return schema
+}
+
+// schemaAt returns the items schema for the given array dimension.
+func schemaAt(schema *Schema, arrayDimension uint8) *Schema {
+	for i := uint8(0); i < arrayDimension; i++ {
+		schema.Type = "array"
+		if schema.Items == nil {
+			schema.Items = &Schema{}
+		}
+		schema = schema.Items
+	}
+	return schema
+}
+
+func applyTypeRule(schema *Schema, rule *validation.Rule) {
+	switch rule.Name {
+	case "array":
+		schema.Type = "array"
+		if schema.Items == nil {
+			schema.Items = &Schema{}
+		}
+		if len(rule.Params) > 0 {
+			applyTypeRule(schema.Items, &validation.Rule{Name: rule.Params[0], Params: rule.Params[1:]})
+		}
+	case "string", "timezone", "json":
+		schema.Type = "string"
+	case "numeric":
+		schema.Type = "number"
+	case "integer":
+		schema.Type = "integer"
+	case "bool":
+		schema.Type = "boolean"
+	case "object":
+		schema.Type = "object"
+	case "file":
+		schema.Type = "string"
+		schema.Format = "binary"
+	case "url":
+		schema.Type = "string"
+		schema.Format = "uri"
+	case "uuid":
+		schema.Type = "string"
+		schema.Format = "uuid"
+	case "ip":
+		schema.Type = "string"
+		schema.Format = "ip"
+	case "ipv4", "ipv6":
+		schema.Type = "string"
+		schema.Format = rule.Name
+	case "date":
+		schema.Type = "string"
+		if len(rule.Params) == 0 || rule.Params[0] == "2006-01-02" {
+			schema.Format = "date"
+		}
+	}
+}
+
+func applyRule(schema *Schema, rule *validation.Rule) {
+	switch rule.Name {
+	case "nullable":
+		schema.Nullable = true
+	case "min":
+		setMin(schema, rule.Params[0])
+	case "max":
+		setMax(schema, rule.Params[0])
+	case "between":
+		setMin(schema, rule.Params[0])
+		setMax(schema, rule.Params[1])
+	case "size":
+		setMin(schema, rule.Params[0])
+		setMax(schema, rule.Params[0])
+	case "in":
+		schema.Enum = make([]interface{}, 0, len(rule.Params))
+		for _, p := range rule.Params {
+			if schema.Type == "number" || schema.Type == "integer" {
+				if f, err := strconv.ParseFloat(p, 64); err == nil {
+					schema.Enum = append(schema.Enum, f)
+					continue
+				}
+			}
+			schema.Enum = append(schema.Enum, p)
+		}
+	case "email":
+		schema.Format = "email"
+	case "regex":
+		schema.Pattern = rule.Params[0]
+	case "digits":
+		schema.Pattern = "^[0-9]*$"
+	case "alpha":
+		schema.Pattern = "^[\\pL\\pM]+$"
+	case "alpha_dash":
+		schema.Pattern = "^[\\pL\\pM0-9_-]+$"
+	case "alpha_num":
+		schema.Pattern = "^[\\pL\\pM0-9]+$"
+	case "starts_with":
+		schema.Pattern = "^(?:" + quoteAll(rule.Params) + ")"
+	case "ends_with":
+		schema.Pattern = "(?:" + quoteAll(rule.Params) + ")$"
+	case "distinct":
+		schema.UniqueItems = true
+	}
+}
+
+func setMin(schema *Schema, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case "number", "integer":
+		schema.Minimum = &value
+	case "string":
+		if schema.Format != "binary" {
+			length := int(value)
+			schema.MinLength = &length
+		}
+	case "array":
+		length := int(value)
+		schema.MinItems = &length
+	}
+}
+
+func setMax(schema *Schema, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	switch schema.Type {
+	case "number", "integer":
+		schema.Maximum = &value
+	case "string":
+		if schema.Format != "binary" {
+			length := int(value)
+			schema.MaxLength = &length
+		}
+	case "array":
+		length := int(value)
+		schema.MaxItems = &length
+	}
+}
+
+func quoteAll(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, regexp.QuoteMeta(v))
+	}
+	return strings.Join(quoted, "|")
+}
+
+func hasRule(field *validation.Field, name string) bool {
+	for _, rule := range field.Rules {
+		if rule.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v3/validation"
+)
+
+func TestFieldSchemaTypes(t *testing.T) {
+	rules := validation.RuleSet{
+		"string":   {"string"},
+		"numeric":  {"numeric"},
+		"integer":  {"integer"},
+		"bool":     {"bool"},
+		"object":   {"object"},
+		"file":     {"file"},
+		"url":      {"url"},
+		"uuid":     {"uuid"},
+		"ipv4":     {"ipv4"},
+		"date":     {"date"},
+		"datetime": {"date:2006-01-02T15:04:05"},
+		"email":    {"string", "email"},
+		"nullable": {"nullable", "string"},
+	}.AsRules()
+
+	cases := map[string][2]string{
+		"string":   {"string", ""},
+		"numeric":  {"number", ""},
+		"integer":  {"integer", ""},
+		"bool":     {"boolean", ""},
+		"object":   {"object", ""},
+		"file":     {"string", "binary"},
+		"url":      {"string", "uri"},
+		"uuid":     {"string", "uuid"},
+		"ipv4":     {"string", "ipv4"},
+		"date":     {"string", "date"},
+		"datetime": {"string", ""},
+		"email":    {"string", "email"},
+		"nullable": {"string", ""},
+	}
+
+	for name, expected := range cases {
+		schema := FieldSchema(rules.Fields[name])
+		assert.Equal(t, expected[0], schema.Type, name)
+		assert.Equal(t, expected[1], schema.Format, name)
+	}
+
+	assert.True(t, FieldSchema(rules.Fields["nullable"]).Nullable)
+}
+
+func TestFieldSchemaConstraints(t *testing.T) {
+	rules := validation.RuleSet{
+		"string":  {"string", "between:3,10"},
+		"numeric": {"numeric", "min:1", "max:5"},
+		"array":   {"array", "size:2", "distinct"},
+		"file":    {"file", "max:1024"},
+		"in":      {"string", "in:a,b"},
+		"inNum":   {"integer", "in:1,2"},
+		"regex":   {"string", "regex:^[a-z]+$"},
+		"digits":  {"digits"},
+		"starts":  {"string", "starts_with:a.,b"},
+	}.AsRules()
+
+	schema := FieldSchema(rules.Fields["string"])
+	assert.Equal(t, 3, *schema.MinLength)
+	assert.Equal(t, 10, *schema.MaxLength)
+
+	schema = FieldSchema(rules.Fields["numeric"])
+	assert.Equal(t, 1.0, *schema.Minimum)
+	assert.Equal(t, 5.0, *schema.Maximum)
+
+	schema = FieldSchema(rules.Fields["array"])
+	assert.Equal(t, 2, *schema.MinItems)
+	assert.Equal(t, 2, *schema.MaxItems)
+	assert.True(t, schema.UniqueItems)
+
+	schema = FieldSchema(rules.Fields["file"])
+	assert.Nil(t, schema.MaxLength)
+
+	assert.Equal(t, []interface{}{"a", "b"}, FieldSchema(rules.Fields["in"]).Enum)
+	assert.Equal(t, []interface{}{1.0, 2.0}, FieldSchema(rules.Fields["inNum"]).Enum)
+	assert.Equal(t, "^[a-z]+$", FieldSchema(rules.Fields["regex"]).Pattern)
+	assert.Equal(t, "^[0-9]*$", FieldSchema(rules.Fields["digits"]).Pattern)
+	assert.Equal(t, "^(?:a\\.|b)", FieldSchema(rules.Fields["starts"]).Pattern)
+}
+
+func TestFieldSchemaArrays(t *testing.T) {
+	rules := validation.RuleSet{
+		"tags":   {"array:string", ">min:2", ">max:20"},
+		"matrix": {"array", ">array:numeric", ">>max:10"},
+	}.AsRules()
+
+	schema := FieldSchema(rules.Fields["tags"])
+	assert.Equal(t, "array", schema.Type)
+	assert.Equal(t, "string", schema.Items.Type)
+	assert.Equal(t, 2, *schema.Items.MinLength)
+	assert.Equal(t, 20, *schema.Items.MaxLength)
+
+	schema = FieldSchema(rules.Fields["matrix"])
+	assert.Equal(t, "array", schema.Type)
+	assert.Equal(t, "array", schema.Items.Type)
+	assert.Equal(t, "number", schema.Items.Items.Type)
+	assert.Equal(t, 10.0, *schema.Items.Items.Maximum)
+}
+
+func TestObjectSchema(t *testing.T) {
+	rules := validation.RuleSet{
+		"user":         {"required", "object"},
+		"user.name":    {"required", "string"},
+		"user.email":   {"string", "email"},
+		"address.city": {"string"},
+	}.AsRules()
+
+	schema := ObjectSchema(rules)
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"user"}, schema.Required)
+
+	user := schema.Properties["user"]
+	assert.Equal(t, "object", user.Type)
+	assert.Equal(t, []string{"name"}, user.Required)
+	assert.Equal(t, "string", user.Properties["name"].Type)
+	assert.Equal(t, "email", user.Properties["email"].Format)
+
+	address := schema.Properties["address"]
+	assert.Equal(t, "object", address.Type)
+	assert.Empty(t, address.Required)
+	assert.Equal(t, "string", address.Properties["city"].Type)
+}
+
+func TestObjectSchemaWildcard(t *testing.T) {
+	rules := validation.RuleSet{
+		"items":                    {"required", "array"},
+		"items[].quantity":         {"required", "integer"},
+		"items[].options[].name":   {"string"},
+		"orders[].items[].product": {"required", "integer"},
+	}.AsRules()
+
+	schema := ObjectSchema(rules)
+	items := schema.Properties["items"]
+	assert.Equal(t, "array", items.Type)
+	assert.Equal(t, "object", items.Items.Type)
+	assert.Equal(t, []string{"quantity"}, items.Items.Required)
+	assert.Equal(t, "integer", items.Items.Properties["quantity"].Type)
+
+	options := items.Items.Properties["options"]
+	assert.Equal(t, "array", options.Type)
+	assert.Equal(t, "string", options.Items.Properties["name"].Type)
+
+	orders := schema.Properties["orders"]
+	assert.Equal(t, "array", orders.Type)
+	assert.Equal(t, "array", orders.Items.Properties["items"].Type)
+	assert.Equal(t, []string{"product"}, orders.Items.Properties["items"].Items.Required)
+}
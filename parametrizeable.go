@@ -4,80 +4,130 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync"
 )
 
 // parameterizable represents a route or router accepting
 // parameters in its URI.
 type parameterizable struct {
-	regex      *regexp.Regexp
+	segments   []*pathSegment
 	parameters []string
+	fallback   *fallbackRegex
 }
 
-// compileParameters parse the route parameters and compiles their regexes if needed.
-// If "ends" is set to true, the generated regex ends with "$", thus set "ends" to true
-// if you're compiling route parameters, set to false if you're compiling router parameters.
-func (p *parameterizable) compileParameters(uri string, ends bool, regexCache map[string]*regexp.Regexp) {
+// fallbackRegex matches a whole URI at once. It is only used when the
+// candidates budget of a constrained parameter is exhausted, and is
+// compiled on first use.
+type fallbackRegex struct {
+	once   sync.Once
+	full   *regexp.Regexp // Anchored at both ends
+	prefix *regexp.Regexp // Only anchored at the start
+}
+
+type segmentKind uint8
+
+const (
+	// segmentStatic is raw text that must be matched as is.
+	segmentStatic segmentKind = iota
+
+	// segmentParam is an unconstrained parameter ("{name}"), matching
+	// one or more characters up to the next slash.
+	segmentParam
+
+	// segmentRegex is a parameter constrained by a pattern ("{name:pattern}").
+	segmentRegex
+
+	// segmentHostParam is an unconstrained parameter in a host pattern,
+	// matching one or more characters up to the next dot.
+	segmentHostParam
+)
+
+// maxRegexCandidates the maximum number of shorter candidates checked for
+// a constrained parameter at a given position when the longest match doesn't
+// lead to a match. Once this budget is exhausted, the whole URI is matched
+// with a single regular expression instead, which takes linear time.
+const maxRegexCandidates = 16
+
+// pathSegment is a piece of a route or router URI. It is either raw text
+// or a parameter, optionally constrained by a regular expression.
+type pathSegment struct {
+	kind segmentKind
+
+	// value is the raw text for static segments and the
+	// pattern for constrained parameters.
+	value string
+
+	// regex is anchored at both ends and is used to check if a
+	// candidate value satisfies the parameter's constraint.
+	regex *regexp.Regexp
+
+	// longest is only anchored at the start and uses leftmost-longest
+	// semantics. It gives the longest possible value for the parameter,
+	// which is used to bound the candidates checked with "regex".
+	longest *regexp.Regexp
+}
+
+// compileParameters parse the route parameters and compiles the regexes
+// of constrained parameters if needed.
+func (p *parameterizable) compileParameters(uri string, regexCache map[string]*regexp.Regexp) {
 	idxs, err := p.braceIndices(uri)
 	if err != nil {
 		panic(err)
 	}
 
-	var builder strings.Builder
+	p.segments = make([]*pathSegment, 0, len(idxs)+1)
+	p.fallback = &fallbackRegex{}
+	length := len(idxs)
+	end := 0
+	for i := 0; i < length; i += 2 {
+		raw := uri[end:idxs[i]]
+		end = idxs[i+1]
+		sub := uri[idxs[i]+1 : end]
+		parts := strings.SplitN(sub, ":", 2)
+		if parts[0] == "" {
+			panic(fmt.Errorf("invalid route parameter, missing name in %q", sub))
+		}
 
-	// Final regex will never be larger than src uri + 2 (for ^ and $)
-	// Make initial alloc to avoid the need for realloc
-	builder.Grow(len(uri) + 2)
+		if raw != "" {
+			p.segments = append(p.segments, &pathSegment{kind: segmentStatic, value: raw})
+		}
 
-	builder.WriteString("^")
-	length := len(idxs)
-	if length > 0 {
-		end := 0
-		for i := 0; i < length; i += 2 {
-			raw := uri[end:idxs[i]]
-			end = idxs[i+1]
-			sub := uri[idxs[i]+1 : end]
-			parts := strings.SplitN(sub, ":", 2)
-			if parts[0] == "" {
-				panic(fmt.Errorf("invalid route parameter, missing name in %q", sub))
+		segment := &pathSegment{kind: segmentParam}
+		if len(parts) == 2 {
+			if parts[1] == "" {
+				panic(fmt.Errorf("invalid route parameter, missing pattern in %q", sub))
 			}
-			pattern := "[^/]+" // default pattern
-			if len(parts) == 2 {
-				pattern = parts[1]
-				if pattern == "" {
-					panic(fmt.Errorf("invalid route parameter, missing pattern in %q", sub))
-				}
+			segment.kind = segmentRegex
+			segment.value = parts[1]
+			segment.regex = compileCached("^(?:"+parts[1]+")$", false, regexCache)
+			segment.longest = compileCached("^(?:"+parts[1]+")", true, regexCache)
+			if segment.regex.NumSubexp() != 0 {
+				panic(fmt.Sprintf("route %s contains capture groups in its regexp. ", uri) +
+					"Only non-capturing groups are accepted: e.g. (?:pattern) instead of (pattern)")
 			}
-
-			builder.WriteString(raw)
-			builder.WriteString("(")
-			builder.WriteString(pattern)
-			builder.WriteString(")")
-			end++ // Skip closing braces
-			p.parameters = append(p.parameters, parts[0])
 		}
-		builder.WriteString(uri[end:])
-	} else {
-		builder.WriteString(uri)
+		p.segments = append(p.segments, segment)
+		p.parameters = append(p.parameters, parts[0])
+		end++ // Skip closing braces
 	}
 
-	if ends {
-		builder.WriteString("$")
+	if end < len(uri) {
+		p.segments = append(p.segments, &pathSegment{kind: segmentStatic, value: uri[end:]})
 	}
+}
 
-	pattern := builder.String()
-	cachedRegex, ok := regexCache[pattern]
-	if !ok {
-		regex := regexp.MustCompile(pattern)
-		regexCache[pattern] = regex
-		p.regex = regex
-	} else {
-		p.regex = cachedRegex
+func compileCached(pattern string, longest bool, regexCache map[string]*regexp.Regexp) *regexp.Regexp {
+	if cachedRegex, ok := regexCache[pattern]; ok {
+		return cachedRegex
 	}
-
-	if p.regex.NumSubexp() != length/2 {
-		panic(fmt.Sprintf("route %s contains capture groups in its regexp. ", uri) +
-			"Only non-capturing groups are accepted: e.g. (?:pattern) instead of (pattern)")
+	regex := regexp.MustCompile(pattern)
+	if longest {
+		regex.Longest()
+	}
+	if regexCache != nil {
+		regexCache[pattern] = regex
 	}
+	return regex
 }
 
 // braceIndices returns the first level curly brace indices from a string.
@@ -110,16 +160,213 @@ func (p *parameterizable) braceIndices(s string) ([]int, error) {
 	return indices, nil
 }
 
-// makeParameters from a regex match and the given parameter names.
-// The match parameter is expected to contain only the capturing groups.
+// matchPath checks if the given path matches the compiled URI.
+// If "prefix" is true, the path only has to start with the URI.
+// Returns the length of the matched part of the path and the
+// parameters' values, in order of appearance.
+func (p *parameterizable) matchPath(path string, prefix bool) (int, []string, bool) {
+	if len(p.segments) == 0 {
+		if prefix || path == "" {
+			return 0, nil, true
+		}
+		return 0, nil, false
+	}
+	exhausted := false
+	length, values, ok := p.matchSegments(path, 0, 0, prefix, make([]string, 0, len(p.parameters)), &exhausted)
+	if exhausted {
+		return p.matchFallback(path, prefix)
+	}
+	return length, values, ok
+}
+
+// matchSegments matches the segments starting at index "i" from the given
+// position of the path. Stops and sets "exhausted" to true if the candidates
+// budget of a constrained parameter is exhausted, in which case the result
+// is not conclusive.
+func (p *parameterizable) matchSegments(path string, pos int, i int, prefix bool, values []string, exhausted *bool) (int, []string, bool) {
+	if i == len(p.segments) {
+		if prefix || pos == len(path) {
+			return pos, values, true
+		}
+		return 0, nil, false
+	}
+
+	segment := p.segments[i]
+	budget := maxRegexCandidates
+	feasible := func(end int) bool { return p.canContinueAt(path, i+1, end) }
+	for end := segment.first(path, pos); end != -1; end = segment.next(path, pos, end, feasible, &budget) {
+		v := values
+		if segment.kind != segmentStatic {
+			v = append(values, path[pos:end])
+		}
+		length, v, ok := p.matchSegments(path, end, i+1, prefix, v, exhausted)
+		if ok || *exhausted {
+			return length, v, ok
+		}
+	}
+	if budget == 0 {
+		*exhausted = true
+	}
+	return 0, nil, false
+}
+
+// matchFallback works like "matchPath" but matches the whole URI with
+// a single regular expression.
+func (p *parameterizable) matchFallback(path string, prefix bool) (int, []string, bool) {
+	p.fallback.once.Do(p.compileFallback)
+	regex := p.fallback.full
+	if prefix {
+		regex = p.fallback.prefix
+	}
+	loc := regex.FindStringSubmatchIndex(path)
+	if loc == nil {
+		return 0, nil, false
+	}
+	values := make([]string, 0, len(p.parameters))
+	for i := 2; i < len(loc); i += 2 {
+		values = append(values, path[loc[i]:loc[i+1]])
+	}
+	return loc[1], values, true
+}
+
+func (p *parameterizable) compileFallback() {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, segment := range p.segments {
+		switch segment.kind {
+		case segmentStatic:
+			b.WriteString(regexp.QuoteMeta(segment.value))
+		case segmentParam:
+			b.WriteString("([^/]+)")
+		case segmentHostParam:
+			b.WriteString("([^.]+)")
+		case segmentRegex:
+			b.WriteString("((?:" + segment.value + "))")
+		}
+	}
+	p.fallback.prefix = regexp.MustCompile(b.String())
+	b.WriteByte('$')
+	p.fallback.full = regexp.MustCompile(b.String())
+}
+
+// canContinueAt returns true if the segment at index "i" can start at the
+// given position of the path. It is cheaper than the matching itself and avoids
+// checking candidates that cannot match. Returns false if "i" is the end of the
+// URI: the longest candidate has already been checked, so shorter candidates
+// cannot reach the end of the path, and any candidate matches if the path
+// only has to start with the URI.
+func (p *parameterizable) canContinueAt(path string, i int, pos int) bool {
+	if i == len(p.segments) {
+		return false
+	}
+	return p.segments[i].canStartAt(path, pos)
+}
+
+// canStartAt returns true if this segment can start at the given position of the path.
+func (s *pathSegment) canStartAt(path string, pos int) bool {
+	switch s.kind {
+	case segmentStatic:
+		return strings.HasPrefix(path[pos:], s.value)
+	case segmentParam:
+		return pos < len(path) && path[pos] != '/'
+	case segmentHostParam:
+		return pos < len(path) && path[pos] != '.'
+	}
+	return true
+}
+
+// first returns the position of the longest possible end of this segment
+// if it starts at "pos" in the given path, or -1 if the segment cannot
+// match at this position.
+// Candidates are given longest first to mimic the greedy behavior of
+// regular expressions.
+func (s *pathSegment) first(path string, pos int) int {
+	switch s.kind {
+	case segmentStatic:
+		if strings.HasPrefix(path[pos:], s.value) {
+			return pos + len(s.value)
+		}
+	case segmentParam, segmentHostParam:
+		separator := byte('/')
+		if s.kind == segmentHostParam {
+			separator = '.'
+		}
+		end := strings.IndexByte(path[pos:], separator)
+		if end == -1 {
+			end = len(path) - pos
+		}
+		if end > 0 {
+			return pos + end
+		}
+	case segmentRegex:
+		if loc := s.longest.FindStringIndex(path[pos:]); loc != nil {
+			return pos + loc[1]
+		}
+	}
+	return -1
+}
+
+// next returns the next possible end of this segment, shorter than "end",
+// or -1 if there is no other candidate. Candidates rejected by the
+// "feasible" function are skipped without being checked.
+//
+// Checking a candidate against the regular expression of a constrained
+// parameter takes linear time. The "budget" is the remaining amount of
+// candidates that can be checked for the current position, which keeps
+// the matching time linear for very long paths. Callers must fall back to
+// "matchFallback" if the budget reaches 0.
+func (s *pathSegment) next(path string, pos int, end int, feasible func(int) bool, budget *int) int {
+	switch s.kind {
+	case segmentParam, segmentHostParam:
+		for end--; end > pos; end-- {
+			if feasible(end) {
+				return end
+			}
+		}
+	case segmentRegex:
+		for end--; end >= pos && *budget > 0; end-- {
+			if feasible(end) {
+				*budget--
+				if s.regex.MatchString(path[pos:end]) {
+					return end
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// fill replaces the parameters in the given URI with the given values,
+// in order of appearance.
+func (p *parameterizable) fill(uri string, parameters []string) string {
+	var builder strings.Builder
+	builder.Grow(len(uri))
+
+	idxs, _ := p.braceIndices(uri)
+	length := len(idxs)
+	end := 0
+	currentParam := 0
+	for i := 0; i < length; i += 2 {
+		raw := uri[end:idxs[i]]
+		end = idxs[i+1]
+		builder.WriteString(raw)
+		builder.WriteString(parameters[currentParam])
+		currentParam++
+		end++ // Skip closing braces
+	}
+	builder.WriteString(uri[end:])
+
+	return builder.String()
+}
+
+// makeParameters from the matched values and the given parameter names.
 //
-// Given ["/product/33/param", "33", "param"] ["id", "name"]
+// Given ["33", "param"] ["id", "name"]
 // The returned map will be ["id": "33", "name": "param"]
-func (p *parameterizable) makeParameters(match []string, names []string) map[string]string {
-	length := len(match)
-	params := make(map[string]string, length-1)
-	for i := 1; i < length; i++ {
-		params[names[i-1]] = match[i]
+func (p *parameterizable) makeParameters(values []string, names []string) map[string]string {
+	params := make(map[string]string, len(values))
+	for i, v := range values {
+		params[names[i]] = v
 	}
 	return params
 }
@@ -2,6 +2,7 @@ package goyave
 
 import (
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -14,51 +15,51 @@ type ParameterizableTestSuite struct {
 func (suite *ParameterizableTestSuite) TestCompileParameters() {
 	regexCache := make(map[string]*regexp.Regexp, 5)
 	p := &parameterizable{}
-	p.compileParameters("/product/{id:[0-9]+}", true, regexCache)
+	p.compileParameters("/product/{id:[0-9]+}", regexCache)
 	suite.Equal([]string{"id"}, p.parameters)
-	suite.NotNil(p.regex)
-	suite.True(p.regex.MatchString("/product/666"))
-	suite.False(p.regex.MatchString("/product/"))
-	suite.False(p.regex.MatchString("/product/qwerty"))
+	suite.Len(p.segments, 2)
+	suite.True(p.matches("/product/666", false))
+	suite.False(p.matches("/product/", false))
+	suite.False(p.matches("/product/qwerty", false))
 
 	p = &parameterizable{}
-	p.compileParameters("/product/{id:[0-9]+}/{name}", true, regexCache)
+	p.compileParameters("/product/{id:[0-9]+}/{name}", regexCache)
 	suite.Equal([]string{"id", "name"}, p.parameters)
-	suite.NotNil(p.regex)
-	suite.False(p.regex.MatchString("/product/666"))
-	suite.False(p.regex.MatchString("/product//"))
-	suite.False(p.regex.MatchString("/product/qwerty"))
-	suite.False(p.regex.MatchString("/product/qwerty/test"))
-	suite.True(p.regex.MatchString("/product/666/test"))
+	suite.Len(p.segments, 4)
+	suite.False(p.matches("/product/666", false))
+	suite.False(p.matches("/product//", false))
+	suite.False(p.matches("/product/qwerty", false))
+	suite.False(p.matches("/product/qwerty/test", false))
+	suite.True(p.matches("/product/666/test", false))
 
 	suite.Panics(func() { // Empty param, expect error
-		p.compileParameters("/product/{}", true, regexCache)
+		p.compileParameters("/product/{}", regexCache)
 	})
 	suite.Panics(func() { // Empty name, expect error
-		p.compileParameters("/product/{:[0-9]+}", true, regexCache)
+		p.compileParameters("/product/{:[0-9]+}", regexCache)
 	})
 	suite.Panics(func() { // Empty pattern, expect error
-		p.compileParameters("/product/{id:}", true, regexCache)
+		p.compileParameters("/product/{id:}", regexCache)
 	})
 	suite.Panics(func() { // Capturing groups
-		p.compileParameters("/product/{name:(.*)}", true, regexCache)
+		p.compileParameters("/product/{name:(.*)}", regexCache)
 	})
 	suite.NotPanics(func() { // Non-capturing groups
-		p.compileParameters("/product/{name:(?:.*)}", true, regexCache)
+		p.compileParameters("/product/{name:(?:.*)}", regexCache)
 	})
 }
 
 func (suite *ParameterizableTestSuite) TestCompileParametersRouter() {
 	regexCache := make(map[string]*regexp.Regexp, 5)
 	p := &parameterizable{}
-	p.compileParameters("/product/{id:[0-9]+}", false, regexCache)
+	p.compileParameters("/product/{id:[0-9]+}", regexCache)
 	suite.Equal([]string{"id"}, p.parameters)
-	suite.NotNil(p.regex)
-	suite.True(p.regex.MatchString("/product/666"))
-	suite.True(p.regex.MatchString("/product/666/extra"))
-	suite.False(p.regex.MatchString("/product/"))
-	suite.False(p.regex.MatchString("/product/qwerty"))
-	suite.False(p.regex.MatchString("/product/qwerty/extra"))
+	suite.Len(p.segments, 2)
+	suite.True(p.matches("/product/666", true))
+	suite.True(p.matches("/product/666/extra", true))
+	suite.False(p.matches("/product/", true))
+	suite.False(p.matches("/product/qwerty", true))
+	suite.False(p.matches("/product/qwerty/extra", true))
 }
 
 func (suite *ParameterizableTestSuite) TestBraceIndices() {
@@ -103,29 +104,130 @@ func (suite *ParameterizableTestSuite) TestBraceIndices() {
 }
 
 func (suite *ParameterizableTestSuite) TestMakeParameters() {
-	matches := []string{"/product/33/param", "33", "param"}
+	values := []string{"33", "param"}
 	names := []string{"id", "name"}
 
 	p := &parameterizable{}
-	params := p.makeParameters(matches, names)
+	params := p.makeParameters(values, names)
 
-	for k := 1; k < len(matches); k++ {
-		suite.Equal(matches[k], params[names[k-1]])
+	for k := 0; k < len(values); k++ {
+		suite.Equal(values[k], params[names[k]])
 	}
 }
 
 func (suite *ParameterizableTestSuite) TestRegexCache() {
 	regexCache := make(map[string]*regexp.Regexp, 5)
 	path := "/product/{id:[0-9]+}"
-	regex := "^/product/([0-9]+)$"
 	p1 := &parameterizable{}
-	p1.compileParameters(path, true, regexCache)
-	suite.NotNil(regexCache[regex])
+	p1.compileParameters(path, regexCache)
+	suite.NotNil(regexCache["^(?:[0-9]+)$"])
+	suite.NotNil(regexCache["^(?:[0-9]+)"])
 
 	p2 := &parameterizable{}
-	p2.compileParameters(path, true, regexCache)
-	suite.Equal(p1.regex, p2.regex)
-	suite.Same(p1.regex, p2.regex)
+	p2.compileParameters(path, regexCache)
+	suite.Same(p1.segments[1].regex, p2.segments[1].regex)
+	suite.Same(p1.segments[1].longest, p2.segments[1].longest)
+}
+
+func (suite *ParameterizableTestSuite) TestCompileParametersSegments() {
+	p := &parameterizable{}
+	p.compileParameters("/product/{id:[0-9]+}/{name}.json", nil)
+	suite.Len(p.segments, 5)
+	suite.Equal(segmentStatic, p.segments[0].kind)
+	suite.Equal("/product/", p.segments[0].value)
+	suite.Equal(segmentRegex, p.segments[1].kind)
+	suite.Equal("[0-9]+", p.segments[1].value)
+	suite.NotNil(p.segments[1].regex)
+	suite.NotNil(p.segments[1].longest)
+	suite.Equal(segmentStatic, p.segments[2].kind)
+	suite.Equal("/", p.segments[2].value)
+	suite.Equal(segmentParam, p.segments[3].kind)
+	suite.Nil(p.segments[3].regex)
+	suite.Equal(segmentStatic, p.segments[4].kind)
+	suite.Equal(".json", p.segments[4].value)
+
+	p = &parameterizable{}
+	p.compileParameters("", nil)
+	suite.Empty(p.segments)
+}
+
+func (suite *ParameterizableTestSuite) TestMatchPath() {
+	p := &parameterizable{}
+	p.compileParameters("/product/{id:[0-9]+}/{name}", nil)
+
+	length, values, ok := p.matchPath("/product/666/test", false)
+	suite.True(ok)
+	suite.Equal(17, length)
+	suite.Equal([]string{"666", "test"}, values)
+
+	length, values, ok = p.matchPath("/product/666/test/extra", true)
+	suite.True(ok)
+	suite.Equal(17, length)
+	suite.Equal([]string{"666", "test"}, values)
+
+	_, values, ok = p.matchPath("/product/666/test/extra", false)
+	suite.False(ok)
+	suite.Nil(values)
+
+	// Greedy, like regular expressions
+	p = &parameterizable{}
+	p.compileParameters("/{name}-{version}", nil)
+	_, values, ok = p.matchPath("/goyave-v3-beta", false)
+	suite.True(ok)
+	suite.Equal([]string{"goyave-v3", "beta"}, values)
+
+	p = &parameterizable{}
+	p.compileParameters("/{name}.{ext:json|xml}", nil)
+	_, values, ok = p.matchPath("/archive.tar.xml", false)
+	suite.True(ok)
+	suite.Equal([]string{"archive.tar", "xml"}, values)
+
+	p = &parameterizable{}
+	p.compileParameters("/{id:[0-9]+}{rest}", nil)
+	_, values, ok = p.matchPath("/123", false)
+	suite.True(ok)
+	suite.Equal([]string{"12", "3"}, values)
+
+	// Constrained parameters can match slashes
+	p = &parameterizable{}
+	p.compileParameters("/static{resource:.*}", nil)
+	_, values, ok = p.matchPath("/static/css/style.css", false)
+	suite.True(ok)
+	suite.Equal([]string{"/css/style.css"}, values)
+	_, values, ok = p.matchPath("/static", false)
+	suite.True(ok)
+	suite.Equal([]string{""}, values)
+
+	// Unconstrained parameters cannot be empty
+	p = &parameterizable{}
+	p.compileParameters("/product/{id}", nil)
+	_, _, ok = p.matchPath("/product/", false)
+	suite.False(ok)
+
+	// Long paths don't backtrack on every position
+	directories := strings.Repeat("directory/", 400)
+	p = &parameterizable{}
+	p.compileParameters("/files/{path:[a-z/]+}/{name:[a-z]+\\.txt}", nil)
+	_, values, ok = p.matchPath("/files/"+directories+"file.txt", false)
+	suite.True(ok)
+	suite.Equal([]string{strings.TrimSuffix(directories, "/"), "file.txt"}, values)
+	_, _, ok = p.matchPath("/files/"+directories+"file.json", false)
+	suite.False(ok)
+
+	// The split lies beyond the candidates budget
+	letters := strings.Repeat("a", 5) + strings.Repeat("b", 20)
+	p = &parameterizable{}
+	p.compileParameters("/{a:[a-z]+}{b:[a-z]{20}}", nil)
+	length, values, ok = p.matchPath("/"+letters, false)
+	suite.True(ok)
+	suite.Equal(26, length)
+	suite.Equal([]string{"aaaaa", strings.Repeat("b", 20)}, values)
+	length, values, ok = p.matchPath("/"+letters+"/extra", true)
+	suite.True(ok)
+	suite.Equal(26, length)
+	suite.Equal([]string{"aaaaa", strings.Repeat("b", 20)}, values)
+	_, _, ok = p.matchPath("/"+strings.Repeat("a", 19), false)
+	suite.False(ok)
 }
 
 func (suite *ParameterizableTestSuite) TestGetParameters() {
@@ -137,6 +239,11 @@ func (suite *ParameterizableTestSuite) TestGetParameters() {
 	suite.NotSame(p.parameters, params)
 }
 
+func (p *parameterizable) matches(path string, prefix bool) bool {
+	_, _, ok := p.matchPath(path, prefix)
+	return ok
+}
+
 func TestParameterizableTestSuite(t *testing.T) {
 	suite.Run(t, new(ParameterizableTestSuite))
 }
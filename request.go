@@ -1,6 +1,7 @@
 package goyave
 
 import (
+	"context"
 	"net"
 	"net/http"
 	"net/url"
@@ -28,6 +29,8 @@ type Request struct {
 	User        interface{}
 	Lang        string
 	cookies     []*http.Cookie
+
+	validationErrorDetails *validation.ErrorDetails
 }
 
 // Request return the raw http request.
@@ -36,6 +39,12 @@ func (r *Request) Request() *http.Request {
 	return r.httpRequest
 }
 
+// Context returns the request's context. It is canceled when the client's
+// connection closes or when the route's timeout is reached.
+func (r *Request) Context() context.Context {
+	return r.httpRequest.Context()
+}
+
 // Method specifies the HTTP method (GET, POST, PUT, etc.).
 func (r *Request) Method() string {
 	return r.httpRequest.Method
@@ -141,6 +150,12 @@ func (r *Request) CORSOptions() *cors.Options {
 	return &cpy
 }
 
+// Param returns the value of the route parameter identified by the given name.
+// Returns an empty string if the parameter doesn't exist.
+func (r *Request) Param(name string) string {
+	return r.Params[name]
+}
+
 // Has check if the given field exists in the request data.
 func (r *Request) Has(field string) bool {
 	_, exists := r.Data[field]
@@ -271,14 +286,29 @@ func (r *Request) ToStruct(dst interface{}) error {
 	return mergo.Map(dst, r.Data)
 }
 
+// ValidationErrorDetails returns the structured validation errors, including the
+// name and parameters of the rules that didn't pass. Returns nil if the request
+// has not been validated or if validation passed.
+func (r *Request) ValidationErrorDetails() *validation.ErrorDetails {
+	return r.validationErrorDetails
+}
+
 func (r *Request) validate() validation.Errors {
 	if r.Rules == nil {
 		return nil
 	}
 
 	contentType := r.httpRequest.Header.Get("Content-Type")
-	errors := validation.Validate(r.Data, r.Rules, strings.HasPrefix(contentType, "application/json"), r.Lang)
+	isJSON := strings.HasPrefix(contentType, "application/json")
+	if r.Data != nil && !isJSON {
+		// Decoders keeping the values' type are validated like JSON
+		if decoder := findBodyDecoder(contentType); decoder != nil {
+			isJSON = decoder.Typed
+		}
+	}
+	errors, details := validation.ValidateWithContext(r.httpRequest.Context(), r, r.Data, r.Rules, isJSON, r.Lang)
 	if len(errors) > 0 {
+		r.validationErrorDetails = details
 		return errors
 	}
 
@@ -135,6 +135,28 @@ func TestRequestValidate(t *testing.T) {
 	assert.Nil(t, errors)
 }
 
+func TestRequestValidateContextRule(t *testing.T) {
+	validation.AddRule("request_test_owned", &validation.RuleDefinition{
+		ContextFunction: func(ctx *validation.Context) bool {
+			request := ctx.Request.(*Request)
+			return ctx.Value == request.Params["id"] && ctx.Context.Err() == nil
+		},
+	})
+
+	rawRequest := httptest.NewRequest("POST", "/test-route", nil)
+	rawRequest.Header.Set("Content-Type", "application/json")
+	request := createTestRequest(rawRequest)
+	request.Params = map[string]string{"id": "1"}
+	request.Data = map[string]interface{}{"id": "1"}
+	request.Rules = validation.RuleSet{"id": {"required", "request_test_owned"}}.AsRules()
+	assert.Nil(t, request.validate())
+
+	request.Data = map[string]interface{}{"id": "2"}
+	errors := request.validate()
+	assert.Contains(t, errors, "id")
+	assert.Equal(t, "request_test_owned", request.ValidationErrorDetails().Get("id")[0].Rule)
+}
+
 func TestRequestAccessors(t *testing.T) {
 	loc, err := time.LoadLocation("America/New_York")
 	if err != nil {
@@ -205,6 +227,13 @@ func TestRequestAccessors(t *testing.T) {
 	assert.Panics(t, func() { request.Object("doesn't exist") })
 }
 
+func TestRequestParam(t *testing.T) {
+	request := createTestRequest(httptest.NewRequest("GET", "/test-route/5", nil))
+	request.Params = map[string]string{"id": "5"}
+	assert.Equal(t, "5", request.Param("id"))
+	assert.Empty(t, request.Param("notaparam"))
+}
+
 func TestRequestHas(t *testing.T) {
 	request := createTestRequest(httptest.NewRequest("POST", "/test-route", nil))
 	request.Data = map[string]interface{}{
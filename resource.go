@@ -0,0 +1,211 @@
+package goyave
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"goyave.dev/goyave/v3/validation"
+)
+
+// IndexController a resource controller listing the resources.
+// Registered as "GET /resources".
+type IndexController interface {
+	Index(response *Response, request *Request)
+}
+
+// ShowController a resource controller returning a single resource.
+// Registered as "GET /resources/{resourceID}".
+type ShowController interface {
+	Show(response *Response, request *Request)
+}
+
+// StoreController a resource controller creating a resource.
+// Registered as "POST /resources".
+type StoreController interface {
+	Store(response *Response, request *Request)
+}
+
+// UpdateController a resource controller updating a resource.
+// Registered as "PUT|PATCH /resources/{resourceID}".
+type UpdateController interface {
+	Update(response *Response, request *Request)
+}
+
+// DestroyController a resource controller deleting a resource.
+// Registered as "DELETE /resources/{resourceID}".
+type DestroyController interface {
+	Destroy(response *Response, request *Request)
+}
+
+// ResourceOptions options for the registration of a resource controller.
+type ResourceOptions struct {
+
+	// Name the prefix of the names of the routes. Defaults to the last
+	// segment of the resource's URI (e.g. "articles" for "/articles").
+	// The name of the parent resource is prepended for nested resources.
+	Name string
+
+	// Parameter the name of the route parameter identifying a resource.
+	// Defaults to the singular form of the last segment of the resource's
+	// URI followed by "ID" (e.g. "articleID" for "/articles").
+	Parameter string
+
+	// Rules the validation rules of each action, identified by their name:
+	// "index", "show", "store", "update" or "destroy".
+	Rules map[string]validation.Ruler
+
+	// Middleware the middleware of each action, identified by their name:
+	// "index", "show", "store", "update" or "destroy".
+	Middleware map[string][]Middleware
+}
+
+// Resource the routes registered for a resource controller.
+type Resource struct {
+	router    *Router
+	routes    map[string]*Route
+	uri       string
+	name      string
+	parameter string
+}
+
+// Resource register the routes of a resource controller. The controller
+// can implement any of the following interfaces, the routes of the
+// other actions are not registered:
+//  Action   Method     URI                      Name
+//  index    GET        /articles                articles.index
+//  store    POST       /articles                articles.store
+//  show     GET        /articles/{articleID}    articles.show
+//  update   PUT|PATCH  /articles/{articleID}    articles.update
+//  destroy  DELETE     /articles/{articleID}    articles.destroy
+//
+// The options can be nil.
+//
+//  type ArticleController struct{}
+//  func (c ArticleController) Index(response *goyave.Response, request *goyave.Request) {}
+//  func (c ArticleController) Store(response *goyave.Response, request *goyave.Request) {}
+//
+//  router.Resource("/articles", ArticleController{}, &goyave.ResourceOptions{
+//    Rules: map[string]validation.Ruler{
+//      "store": article.StoreRequest,
+//    },
+//    Middleware: map[string][]goyave.Middleware{
+//      "store": {authenticator},
+//    },
+//  })
+//
+// Panics if the controller doesn't implement any action, or if the options
+// reference an action the controller doesn't implement.
+func (r *Router) Resource(uri string, controller interface{}, options *ResourceOptions) *Resource {
+	return r.registerResource("", "", uri, controller, options)
+}
+
+func (r *Router) registerResource(prefix string, namePrefix string, uri string, controller interface{}, options *ResourceOptions) *Resource {
+	if options == nil {
+		options = &ResourceOptions{}
+	}
+
+	segment := uri[strings.LastIndex(uri, "/")+1:]
+	resource := &Resource{
+		router:    r,
+		routes:    make(map[string]*Route, 5),
+		uri:       prefix + uri,
+		name:      options.Name,
+		parameter: options.Parameter,
+	}
+	if resource.name == "" {
+		resource.name = segment
+	}
+	resource.name = namePrefix + resource.name
+	if resource.parameter == "" {
+		resource.parameter = singularize(segment) + "ID"
+	}
+	itemURI := resource.uri + "/{" + resource.parameter + "}"
+
+	if c, ok := controller.(IndexController); ok {
+		resource.register("index", http.MethodGet, resource.uri, c.Index)
+	}
+	if c, ok := controller.(StoreController); ok {
+		resource.register("store", http.MethodPost, resource.uri, c.Store)
+	}
+	if c, ok := controller.(ShowController); ok {
+		resource.register("show", http.MethodGet, itemURI, c.Show)
+	}
+	if c, ok := controller.(UpdateController); ok {
+		resource.register("update", "PUT|PATCH", itemURI, c.Update)
+	}
+	if c, ok := controller.(DestroyController); ok {
+		resource.register("destroy", http.MethodDelete, itemURI, c.Destroy)
+	}
+
+	if len(resource.routes) == 0 {
+		panic(fmt.Errorf("Resource controller %T doesn't implement any action", controller))
+	}
+
+	for action, rules := range options.Rules {
+		resource.getAction(action).Validate(rules)
+	}
+	for action, middleware := range options.Middleware {
+		resource.getAction(action).Middleware(middleware...)
+	}
+
+	return resource
+}
+
+func (r *Resource) register(action string, methods string, uri string, handler Handler) {
+	r.routes[action] = r.router.Route(methods, uri, handler).Name(r.name + "." + action)
+}
+
+func (r *Resource) getAction(action string) *Route {
+	route, ok := r.routes[action]
+	if !ok {
+		panic(fmt.Errorf("Resource %q doesn't have the %q action", r.name, action))
+	}
+	return route
+}
+
+// Resource register a resource controller nested in this resource.
+// Its URI is prefixed with the URI of a single resource of this one,
+// and its name is prefixed with the name of this resource. The routes
+// are registered in the same router.
+//
+//  articles := router.Resource("/articles", ArticleController{}, nil)
+//  articles.Resource("/comments", CommentController{}, nil)
+//  // GET /articles/{articleID}/comments/{commentID} articles.comments.show
+func (r *Resource) Resource(uri string, controller interface{}, options *ResourceOptions) *Resource {
+	prefix := r.uri + "/{" + r.parameter + "}"
+	return r.router.registerResource(prefix, r.name+".", uri, controller, options)
+}
+
+// GetRoute returns the route registered for the given action
+// ("index", "show", "store", "update" or "destroy").
+// Returns nil if the controller doesn't implement this action.
+func (r *Resource) GetRoute(action string) *Route {
+	return r.routes[action]
+}
+
+// GetName returns the prefix of the names of the routes of this resource,
+// without the name prefix of the router (see "Router.NamePrefix").
+func (r *Resource) GetName() string {
+	return r.name
+}
+
+// GetParameter returns the name of the route parameter identifying
+// a single resource.
+func (r *Resource) GetParameter() string {
+	return r.parameter
+}
+
+// singularize returns the singular form of regular English plural words.
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return word[:len(word)-1]
+	}
+	return word
+}
@@ -0,0 +1,214 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"goyave.dev/goyave/v3/validation"
+)
+
+type fullResourceController struct{}
+
+func (c fullResourceController) Index(response *Response, request *Request) {
+	response.String(http.StatusOK, "index")
+}
+
+func (c fullResourceController) Show(response *Response, request *Request) {
+	response.String(http.StatusOK, "show "+request.Params["articleID"])
+}
+
+func (c fullResourceController) Store(response *Response, request *Request) {
+	response.String(http.StatusCreated, "store")
+}
+
+func (c fullResourceController) Update(response *Response, request *Request) {
+	response.String(http.StatusOK, "update "+request.Params["articleID"])
+}
+
+func (c fullResourceController) Destroy(response *Response, request *Request) {
+	response.String(http.StatusOK, "destroy "+request.Params["articleID"])
+}
+
+type readOnlyResourceController struct{}
+
+func (c readOnlyResourceController) Index(response *Response, request *Request) {
+	response.String(http.StatusOK, "comments of "+request.Params["articleID"])
+}
+
+func (c readOnlyResourceController) Show(response *Response, request *Request) {
+	response.String(http.StatusOK, "comment "+request.Params["commentID"]+" of "+request.Params["articleID"])
+}
+
+type ResourceTestSuite struct {
+	TestSuite
+}
+
+func (suite *ResourceTestSuite) serve(router *Router, method, uri string) (int, string) {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, uri, nil))
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, string(body)
+}
+
+func (suite *ResourceTestSuite) TestResource() {
+	router := NewRouter()
+	resource := router.Resource("/articles", fullResourceController{}, nil)
+	suite.Equal("articles", resource.GetName())
+	suite.Equal("articleID", resource.GetParameter())
+
+	expected := map[string][]string{
+		"index":   {"GET", "HEAD"},
+		"store":   {"POST"},
+		"show":    {"GET", "HEAD"},
+		"update":  {"PUT", "PATCH"},
+		"destroy": {"DELETE"},
+	}
+	for action, methods := range expected {
+		route := resource.GetRoute(action)
+		if suite.NotNil(route, action) {
+			suite.Equal(methods, route.GetMethods())
+			suite.Equal("articles."+action, route.GetName())
+			suite.Same(route, router.GetRoute("articles."+action))
+		}
+	}
+	suite.Equal("/articles", resource.GetRoute("index").GetFullURI())
+	suite.Equal("/articles/{articleID}", resource.GetRoute("show").GetFullURI())
+	suite.Equal(BaseURL()+"/articles/42", router.GetRoute("articles.show").BuildURL("42"))
+
+	status, body := suite.serve(router, "GET", "/articles")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("index", body)
+
+	status, body = suite.serve(router, "POST", "/articles")
+	suite.Equal(http.StatusCreated, status)
+	suite.Equal("store", body)
+
+	status, body = suite.serve(router, "GET", "/articles/42")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("show 42", body)
+
+	_, body = suite.serve(router, "PATCH", "/articles/42")
+	suite.Equal("update 42", body)
+
+	_, body = suite.serve(router, "PUT", "/articles/42")
+	suite.Equal("update 42", body)
+
+	_, body = suite.serve(router, "DELETE", "/articles/42")
+	suite.Equal("destroy 42", body)
+}
+
+func (suite *ResourceTestSuite) TestPartialResource() {
+	router := NewRouter()
+	resource := router.Resource("/categories", readOnlyResourceController{}, nil)
+	suite.Equal("categoryID", resource.GetParameter())
+	suite.NotNil(resource.GetRoute("index"))
+	suite.NotNil(resource.GetRoute("show"))
+	suite.Nil(resource.GetRoute("store"))
+	suite.Nil(resource.GetRoute("update"))
+	suite.Nil(resource.GetRoute("destroy"))
+
+	status, _ := suite.serve(router, "POST", "/categories")
+	suite.Equal(http.StatusMethodNotAllowed, status)
+
+	suite.Panics(func() {
+		router.Resource("/empty", struct{}{}, nil)
+	})
+
+	// Options reference an action that is not implemented
+	suite.Panics(func() {
+		router.Resource("/tags", readOnlyResourceController{}, &ResourceOptions{
+			Rules: map[string]validation.Ruler{"store": validation.RuleSet{}},
+		})
+	})
+}
+
+func (suite *ResourceTestSuite) TestResourceOptions() {
+	router := NewRouter()
+	rules := validation.RuleSet{"title": {"required", "string"}}
+	middleware := func(next Handler) Handler {
+		return func(response *Response, r *Request) {
+			response.Header().Set("X-Middleware", "store")
+			next(response, r)
+		}
+	}
+	resource := router.Resource("/articles", fullResourceController{}, &ResourceOptions{
+		Name:      "posts",
+		Parameter: "id",
+		Rules: map[string]validation.Ruler{
+			"store":  rules,
+			"update": rules,
+		},
+		Middleware: map[string][]Middleware{
+			"store": {middleware},
+		},
+	})
+	suite.Equal("posts", resource.GetName())
+	suite.Equal("id", resource.GetParameter())
+	suite.Equal("/articles/{id}", router.GetRoute("posts.show").GetFullURI())
+	suite.Equal(rules.AsRules(), resource.GetRoute("store").GetValidationRules())
+	suite.Equal(rules.AsRules(), resource.GetRoute("update").GetValidationRules())
+	suite.Nil(resource.GetRoute("index").GetValidationRules())
+	suite.Len(resource.GetRoute("store").middleware, 1)
+	suite.Empty(resource.GetRoute("index").middleware)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("POST", "/articles", nil))
+	resp := recorder.Result()
+	resp.Body.Close()
+	suite.Equal(http.StatusUnprocessableEntity, resp.StatusCode)
+	suite.Equal("store", resp.Header.Get("X-Middleware"))
+}
+
+func (suite *ResourceTestSuite) TestNestedResource() {
+	router := NewRouter()
+	articles := router.Resource("/articles", fullResourceController{}, nil)
+	comments := articles.Resource("/comments", readOnlyResourceController{}, nil)
+	suite.Equal("articles.comments", comments.GetName())
+	suite.Equal("commentID", comments.GetParameter())
+	suite.Equal("/articles/{articleID}/comments", router.GetRoute("articles.comments.index").GetFullURI())
+	suite.Equal("/articles/{articleID}/comments/{commentID}", router.GetRoute("articles.comments.show").GetFullURI())
+	suite.Equal(BaseURL()+"/articles/1/comments/2", comments.GetRoute("show").BuildURL("1", "2"))
+
+	status, body := suite.serve(router, "GET", "/articles/1/comments")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("comments of 1", body)
+
+	status, body = suite.serve(router, "GET", "/articles/1/comments/2")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("comment 2 of 1", body)
+
+	// The parent resource is still reachable
+	status, body = suite.serve(router, "GET", "/articles/1")
+	suite.Equal(http.StatusOK, status)
+	suite.Equal("show 1", body)
+
+	// Resources registered in subrouters
+	api := router.Subrouter("/api")
+	api.Resource("/articles", fullResourceController{}, &ResourceOptions{Name: "api.articles"})
+	_, body = suite.serve(router, "GET", "/api/articles/3")
+	suite.Equal("show 3", body)
+	suite.Equal(BaseURL()+"/api/articles/3", router.GetRoute("api.articles.show").BuildURL("3"))
+}
+
+func (suite *ResourceTestSuite) TestSingularize() {
+	suite.Equal("article", singularize("articles"))
+	suite.Equal("category", singularize("categories"))
+	suite.Equal("address", singularize("addresses"))
+	suite.Equal("box", singularize("boxes"))
+	suite.Equal("match", singularize("matches"))
+	suite.Equal("wish", singularize("wishes"))
+	suite.Equal("response", singularize("responses"))
+	suite.Equal("class", singularize("class"))
+	suite.Equal("data", singularize("data"))
+}
+
+func TestResourceSuite(t *testing.T) {
+	RunTest(t, new(ResourceTestSuite))
+}
@@ -3,17 +3,21 @@ package goyave
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	htmltemplate "html/template"
 	"io"
+	"io/fs"
+	"io/ioutil"
 	"net"
 	"net/http"
 	"os"
 	"runtime/debug"
 	"strconv"
-	"text/template"
+	"time"
 
 	"gorm.io/gorm"
 	"goyave.dev/goyave/v3/config"
@@ -46,9 +50,10 @@ type Response struct {
 	// Used to check if controller didn't write anything so
 	// core can write default 204 No Content.
 	// See RFC 7231, 6.3.5
-	empty       bool
-	wroteHeader bool
-	hijacked    bool
+	empty          bool
+	wroteHeader    bool
+	hijacked       bool
+	headerDeferred int
 }
 
 // newResponse create a new Response using the given http.ResponseWriter and raw request.
@@ -96,14 +101,41 @@ func (r *Response) Write(data []byte) (int, error) {
 // status code.
 // Prefer using "Status()" method instead.
 // Calling this method a second time will have no effect.
+//
+// If the header is deferred (see "DeferHeader()"), only the status
+// is recorded and the header is written once it is released.
 func (r *Response) WriteHeader(status int) {
 	if !r.wroteHeader {
 		r.status = status
+		if r.headerDeferred > 0 {
+			return
+		}
 		r.wroteHeader = true
 		r.responseWriter.WriteHeader(status)
 	}
 }
 
+// DeferHeader prevents the response header from being written when the
+// body is written or flushed, or when "WriteHeader()" is called. This is meant
+// for chained writers buffering the body, which need to alter the headers or
+// the status once the body is complete. Such writers are responsible for calling
+// "ReleaseHeader()" and "WriteHeader()" when they release the body, at the latest
+// when they are closed.
+//
+// Calls can be nested: the header stays deferred until
+// every writer that deferred it released it.
+func (r *Response) DeferHeader() {
+	r.headerDeferred++
+}
+
+// ReleaseHeader cancels the effect of one call to "DeferHeader()". If the header
+// is not deferred anymore, it is written on the next write or call to "WriteHeader()".
+func (r *Response) ReleaseHeader() {
+	if r.headerDeferred > 0 {
+		r.headerDeferred--
+	}
+}
+
 // Header returns the header map that will be sent.
 func (r *Response) Header() http.Header {
 	return r.responseWriter.Header()
@@ -143,6 +175,27 @@ func (r *Response) Hijacked() bool {
 	return r.hijacked
 }
 
+// --------------------------------------
+// http.Flusher implementation
+
+// Flush sends any buffered data to the client. The response header is
+// written first if it hasn't been written yet.
+//
+// If the current writer implements http.Flusher, its Flush method is
+// called so chained writers (compression, logging) can flush their own
+// buffers and propagate the call to their child writer. Otherwise, the
+// original http.ResponseWriter is flushed if it supports it.
+func (r *Response) Flush() {
+	if !r.wroteHeader {
+		r.PreWrite(nil)
+	}
+	if flusher, ok := r.writer.(http.Flusher); ok {
+		flusher.Flush()
+	} else if flusher, ok := r.responseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // --------------------------------------
 // Chained writers
 
@@ -226,14 +279,22 @@ func (r *Response) String(responseCode int, message string) error {
 	return err
 }
 
-func (r *Response) writeFile(file string, disposition string) (int64, error) {
-	if !filesystem.FileExists(file) {
+// osFS opens files using the OS filesystem directly, so paths
+// can be absolute or relative to the working directory.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+func (r *Response) writeFile(fsys fs.FS, file string, disposition string) error {
+	if !filesystem.FileExistsFS(fsys, file) {
 		r.Status(http.StatusNotFound)
-		return 0, &os.PathError{Op: "open", Path: file, Err: fmt.Errorf("no such file or directory")}
+		return &os.PathError{Op: "open", Path: file, Err: fmt.Errorf("no such file or directory")}
 	}
 	r.empty = false
 	r.status = http.StatusOK
-	mime, size := filesystem.GetMIMEType(file)
+	mime, size := filesystem.GetMIMETypeFS(fsys, file)
 	header := r.responseWriter.Header()
 	header.Set("Content-Disposition", disposition)
 
@@ -241,13 +302,69 @@ func (r *Response) writeFile(file string, disposition string) (int64, error) {
 		header.Set("Content-Type", mime)
 	}
 
-	header.Set("Content-Length", strconv.FormatInt(size, 10))
-
-	f, _ := os.Open(file)
-	// No need to check for errors, filesystem.FileExists(file) and
-	// filesystem.GetMIMEType(file) already handled that.
+	f, _ := fsys.Open(file)
+	// No need to check for errors, filesystem.FileExistsFS(file) and
+	// filesystem.GetMIMETypeFS(file) already handled that.
 	defer f.Close()
-	return io.Copy(r, f)
+	stat, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		// Files from some filesystems cannot seek, which is required
+		// to serve byte ranges.
+		b, err := ioutil.ReadAll(f)
+		if err != nil {
+			return err
+		}
+		content = bytes.NewReader(b)
+	}
+
+	if header.Get("ETag") == "" {
+		if stat.ModTime().IsZero() {
+			// Files without modification time (such as embedded files)
+			// are identified by their content instead.
+			etag, err := contentETag(content)
+			if err != nil {
+				return err
+			}
+			header.Set("ETag", etag)
+		} else {
+			header.Set("ETag", fileETag(stat.ModTime(), size))
+		}
+	}
+
+	request := r.httpRequest
+	if request == nil {
+		request = &http.Request{Method: http.MethodGet, Header: http.Header{}}
+	}
+
+	// ServeContent handles "If-Match", "If-None-Match", "If-Modified-Since",
+	// "If-Unmodified-Since", "If-Range" and "Range" headers and sets
+	// "Last-Modified", "Accept-Ranges" and "Content-Length".
+	http.ServeContent(r, request, "", stat.ModTime(), content)
+	return nil
+}
+
+// fileETag generates a strong entity tag from the modification
+// time and the size of a file.
+func fileETag(modTime time.Time, size int64) string {
+	return "\"" + strconv.FormatInt(modTime.UnixNano(), 16) + "-" + strconv.FormatInt(size, 16) + "\""
+}
+
+// contentETag generates a strong entity tag from the SHA-1 hash of the
+// given content, then rewinds it.
+func contentETag(content io.ReadSeeker) (string, error) {
+	hash := sha1.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return "\"" + hex.EncodeToString(hash.Sum(nil)) + "\"", nil
 }
 
 // File write a file as an inline element.
@@ -255,27 +372,46 @@ func (r *Response) writeFile(file string, disposition string) (int64, error) {
 // If the file doesn't exist, respond with status 404 Not Found.
 // The given path can be relative or absolute.
 //
+// The "ETag" and "Last-Modified" headers are set so conditional requests are supported
+// ("304 Not Modified", "412 Precondition Failed"), as well as byte ranges ("206 Partial Content"),
+// including multiple ranges sent as "multipart/byteranges".
+//
 // If you want the file to be sent as a download ("Content-Disposition: attachment"), use the "Download" function instead.
 func (r *Response) File(file string) error {
-	_, err := r.writeFile(file, "inline")
-	return err
+	return r.writeFile(osFS{}, file, "inline")
+}
+
+// FileFS works like "File" but reads the file from the given filesystem,
+// for example files embedded in the binary with "embed.FS".
+// The given path must be valid according to "fs.ValidPath".
+func (r *Response) FileFS(fsys fs.FS, file string) error {
+	return r.writeFile(fsys, file, "inline")
 }
 
 // Download write a file as an attachment element.
 // Automatically detects the file MIME type and sets the "Content-Type" header accordingly.
 // If the file doesn't exist, respond with status 404 Not Found.
 // The given path can be relative or absolute.
+// Conditional requests and byte ranges are supported, like with "File".
 //
 // The "fileName" parameter defines the name the client will see. In other words, it sets the header "Content-Disposition" to
 // "attachment; filename="${fileName}""
 //
 // If you want the file to be sent as an inline element ("Content-Disposition: inline"), use the "File" function instead.
 func (r *Response) Download(file string, fileName string) error {
-	_, err := r.writeFile(file, fmt.Sprintf("attachment; filename=\"%s\"", fileName))
-	return err
+	return r.writeFile(osFS{}, file, fmt.Sprintf("attachment; filename=\"%s\"", fileName))
+}
+
+// DownloadFS works like "Download" but reads the file from the given filesystem,
+// for example files embedded in the binary with "embed.FS".
+// The given path must be valid according to "fs.ValidPath".
+func (r *Response) DownloadFS(fsys fs.FS, file string, fileName string) error {
+	return r.writeFile(fsys, file, fmt.Sprintf("attachment; filename=\"%s\"", fileName))
 }
 
 // Error print the error in the console and return it with an error code 500.
+// If the error is caused by an exceeded context deadline ("context.DeadlineExceeded"),
+// for example an outgoing request that timed out, the error code is 504 instead.
 // If debugging is enabled in the config, the error is also written in the response
 // and the stacktrace is printed in the console.
 // If debugging is not enabled, only the status code is set, which means you can still
@@ -287,6 +423,10 @@ func (r *Response) Error(err interface{}) error {
 
 func (r *Response) error(err interface{}) error {
 	r.err = err
+	status := http.StatusInternalServerError
+	if e, ok := err.(error); ok && errors.Is(e, context.DeadlineExceeded) {
+		status = http.StatusGatewayTimeout
+	}
 	if config.GetBool("app.debug") {
 		stacktrace := r.stacktrace
 		if stacktrace == "" {
@@ -300,12 +440,12 @@ func (r *Response) error(err interface{}) error {
 			} else {
 				message = err
 			}
-			return r.JSON(http.StatusInternalServerError, map[string]interface{}{"error": message})
+			return r.JSON(status, map[string]interface{}{"error": message})
 		}
 	}
 
 	// Don't set r.empty to false to let error status handler process the error
-	r.Status(http.StatusInternalServerError)
+	r.Status(status)
 	return nil
 }
 
@@ -328,14 +468,12 @@ func (r *Response) TemporaryRedirect(url string) {
 
 // Render a text template with the given data.
 // The template path is relative to the "resources/template" directory.
+//
+// Templates are parsed once and kept in cache. If "app.debug" is enabled,
+// they are parsed again when a file in the template directory changes.
 func (r *Response) Render(responseCode int, templatePath string, data interface{}) error {
-	tmplt, err := template.ParseFiles(r.getTemplateDirectory() + templatePath)
-	if err != nil {
-		return err
-	}
-
 	var b bytes.Buffer
-	if err := tmplt.Execute(&b, data); err != nil {
+	if err := getViews(r.getTemplateDirectory()).executeText(&b, templatePath, data); err != nil {
 		return err
 	}
 
@@ -344,14 +482,17 @@ func (r *Response) Render(responseCode int, templatePath string, data interface{
 
 // RenderHTML an HTML template with the given data.
 // The template path is relative to the "resources/template" directory.
+//
+// Templates in the "layouts" and "partials" directories are available in all
+// the other templates. A page can use a layout by defining the blocks it declares:
+//
+//  {{define "content"}}<p>{{.Message}}</p>{{end}}
+//  {{template "layouts/app.html" .}}
+//
+// See "RegisterTemplateFunc" for the available functions.
 func (r *Response) RenderHTML(responseCode int, templatePath string, data interface{}) error {
-	tmplt, err := htmltemplate.ParseFiles(r.getTemplateDirectory() + templatePath)
-	if err != nil {
-		return err
-	}
-
 	var b bytes.Buffer
-	if err := tmplt.Execute(&b, data); err != nil {
+	if err := getViews(r.getTemplateDirectory()).executeHTML(&b, templatePath, data); err != nil {
 		return err
 	}
 
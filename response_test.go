@@ -2,10 +2,14 @@ package goyave
 
 import (
 	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -13,6 +17,8 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"gorm.io/gorm"
 	"goyave.dev/goyave/v3/config"
@@ -156,6 +162,48 @@ func (suite *ResponseTestSuite) TestIsHeaderWritten() {
 	suite.True(response.IsHeaderWritten())
 }
 
+func (suite *ResponseTestSuite) TestDeferHeader() {
+	recorder := httptest.NewRecorder()
+	response := newResponse(recorder, nil)
+	buffer := &bytes.Buffer{}
+	response.SetWriter(buffer)
+	response.DeferHeader()
+	response.String(http.StatusCreated, "test")
+	suite.False(response.IsHeaderWritten())
+	suite.False(response.IsEmpty())
+	suite.Equal(http.StatusCreated, response.GetStatus())
+	suite.Equal("test", buffer.String())
+
+	response.ReleaseHeader()
+	response.Write([]byte("test"))
+	suite.True(response.IsHeaderWritten())
+	suite.Equal(http.StatusCreated, recorder.Code)
+
+	// Nested
+	recorder = httptest.NewRecorder()
+	response = newResponse(recorder, nil)
+	response.DeferHeader()
+	response.DeferHeader()
+	response.ReleaseHeader()
+	response.WriteHeader(http.StatusAccepted)
+	suite.False(response.IsHeaderWritten())
+	suite.Equal(http.StatusAccepted, response.GetStatus())
+	response.ReleaseHeader()
+	response.ReleaseHeader() // No effect
+	response.WriteHeader(http.StatusCreated)
+	suite.True(response.IsHeaderWritten())
+	suite.Equal(http.StatusCreated, recorder.Code)
+
+	// Header written by finalize after closing the writers
+	recorder = httptest.NewRecorder()
+	response = newResponse(recorder, nil)
+	response.DeferHeader()
+	response.Status(http.StatusAccepted)
+	NewRouter().finalize(response, &Request{})
+	suite.True(response.IsHeaderWritten())
+	suite.Equal(http.StatusAccepted, recorder.Code)
+}
+
 func (suite *ResponseTestSuite) TestGetStacktrace() {
 	rawRequest := httptest.NewRequest("GET", "/test-route", strings.NewReader("body"))
 	response := newResponse(httptest.NewRecorder(), rawRequest)
@@ -204,6 +252,161 @@ func (suite *ResponseTestSuite) TestResponseFile() {
 	resp.Body.Close()
 }
 
+func (suite *ResponseTestSuite) TestResponseFileConditional() {
+	file := "config/config.test.json"
+	stat, err := os.Stat(file)
+	if err != nil {
+		panic(err)
+	}
+	etag := fileETag(stat.ModTime(), stat.Size())
+	lastModified := stat.ModTime().UTC().Format(http.TimeFormat)
+
+	rawRequest := httptest.NewRequest("GET", "/test-route", nil)
+	response := newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	resp := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(etag, resp.Header.Get("ETag"))
+	suite.Equal(lastModified, resp.Header.Get("Last-Modified"))
+	suite.Equal("bytes", resp.Header.Get("Accept-Ranges"))
+	resp.Body.Close()
+
+	// If-None-Match
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-None-Match", "\"other\", "+etag)
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal(http.StatusNotModified, resp.StatusCode)
+	suite.Equal(http.StatusNotModified, response.GetStatus())
+	suite.Equal(etag, resp.Header.Get("ETag"))
+	suite.Empty(body)
+
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-None-Match", "\"other\"")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusOK, response.GetStatus())
+
+	// If-Modified-Since
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-Modified-Since", lastModified)
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusNotModified, response.GetStatus())
+
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-Modified-Since", stat.ModTime().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusOK, response.GetStatus())
+
+	// If-Match
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-Match", "\"other\"")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.Download(file, "config.json"))
+	suite.Equal(http.StatusPreconditionFailed, response.GetStatus())
+
+	// Preset ETag is not overridden
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-None-Match", "\"custom\"")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	response.Header().Set("ETag", "\"custom\"")
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusNotModified, response.GetStatus())
+
+	// No request
+	response = newResponse(httptest.NewRecorder(), nil)
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusOK, response.GetStatus())
+}
+
+func (suite *ResponseTestSuite) TestResponseFileRange() {
+	file := "config/config.test.json"
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		panic(err)
+	}
+	stat, err := os.Stat(file)
+	if err != nil {
+		panic(err)
+	}
+
+	rawRequest := httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", "bytes=2-9")
+	response := newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.Download(file, "config.json"))
+	resp := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal(http.StatusPartialContent, resp.StatusCode)
+	suite.Equal(fmt.Sprintf("bytes 2-9/%d", len(content)), resp.Header.Get("Content-Range"))
+	suite.Equal("8", resp.Header.Get("Content-Length"))
+	suite.Equal("attachment; filename=\"config.json\"", resp.Header.Get("Content-Disposition"))
+	suite.Equal(string(content[2:10]), string(body))
+
+	// Multiple ranges
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", "bytes=0-1,-3")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	suite.Equal(http.StatusPartialContent, resp.StatusCode)
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	suite.Nil(err)
+	suite.Equal("multipart/byteranges", mediaType)
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	expected := []string{string(content[0:2]), string(content[len(content)-3:])}
+	for _, e := range expected {
+		part, err := reader.NextPart()
+		if !suite.Nil(err) {
+			break
+		}
+		suite.Equal("application/json", part.Header.Get("Content-Type"))
+		b, err := ioutil.ReadAll(part)
+		suite.Nil(err)
+		suite.Equal(e, string(b))
+	}
+	_, err = reader.NextPart()
+	suite.Equal(io.EOF, err)
+	resp.Body.Close()
+
+	// Unsatisfiable range
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", fmt.Sprintf("bytes=%d-", len(content)+10))
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	resp.Body.Close()
+	suite.Equal(http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	suite.Equal(fmt.Sprintf("bytes */%d", len(content)), resp.Header.Get("Content-Range"))
+
+	// If-Range
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", "bytes=2-9")
+	rawRequest.Header.Set("If-Range", fileETag(stat.ModTime(), stat.Size()))
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	suite.Equal(http.StatusPartialContent, response.GetStatus())
+
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", "bytes=2-9")
+	rawRequest.Header.Set("If-Range", "\"outdated\"")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.File(file))
+	resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(content, body)
+}
+
 func (suite *ResponseTestSuite) TestResponseJSON() {
 	rawRequest := httptest.NewRequest("GET", "/test-route", strings.NewReader("body"))
 	response := newResponse(httptest.NewRecorder(), rawRequest)
@@ -226,6 +429,82 @@ func (suite *ResponseTestSuite) TestResponseJSON() {
 	suite.Equal("{\"code\":200,\"status\":\"ok\"}\n", string(body))
 }
 
+// nonSeekableFS hides the "Seek" method of the files it opens.
+type nonSeekableFS struct {
+	fs.FS
+}
+
+func (f nonSeekableFS) Open(name string) (fs.File, error) {
+	file, err := f.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return struct{ fs.File }{file}, nil
+}
+
+func (suite *ResponseTestSuite) TestResponseFileFS() {
+	fsys := fstest.MapFS{
+		"css/app.css": {Data: []byte("body{ margin:0; }"), ModTime: time.Date(2021, 5, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	rawRequest := httptest.NewRequest("GET", "/test-route", nil)
+	response := newResponse(httptest.NewRecorder(), rawRequest)
+
+	suite.Nil(response.FileFS(fsys, "css/app.css"))
+	resp := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal(200, resp.StatusCode)
+	suite.Equal("inline", resp.Header.Get("Content-Disposition"))
+	suite.Equal("text/css", resp.Header.Get("Content-Type"))
+	suite.Equal("Sat, 01 May 2021 12:00:00 GMT", resp.Header.Get("Last-Modified"))
+	suite.Equal("body{ margin:0; }", string(body))
+
+	// Files that cannot seek still support ranges
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("Range", "bytes=0-3")
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.DownloadFS(nonSeekableFS{fsys}, "css/app.css", "app.css"))
+	resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal(http.StatusPartialContent, resp.StatusCode)
+	suite.Equal("attachment; filename=\"app.css\"", resp.Header.Get("Content-Disposition"))
+	suite.Equal("body", string(body))
+
+	// Files without modification time (embed.FS) are tagged by their content
+	fsys["js/a.js"] = &fstest.MapFile{Data: []byte("alert('a')")}
+	fsys["js/b.js"] = &fstest.MapFile{Data: []byte("alert('b')")}
+	etags := make([]string, 0, 2)
+	for _, file := range []string{"js/a.js", "js/b.js"} {
+		response = newResponse(httptest.NewRecorder(), httptest.NewRequest("GET", "/test-route", nil))
+		suite.Nil(response.FileFS(fsys, file))
+		resp = response.responseWriter.(*httptest.ResponseRecorder).Result()
+		body, err = ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		suite.Nil(err)
+		suite.Equal(string(fsys[file].Data), string(body))
+		suite.Empty(resp.Header.Get("Last-Modified"))
+		etags = append(etags, resp.Header.Get("ETag"))
+	}
+	suite.Equal("\"0563a091453a2f95f3af30419eb16cc916fa61b4\"", etags[0])
+	suite.NotEqual(etags[0], etags[1])
+
+	rawRequest = httptest.NewRequest("GET", "/test-route", nil)
+	rawRequest.Header.Set("If-None-Match", etags[0])
+	response = newResponse(httptest.NewRecorder(), rawRequest)
+	suite.Nil(response.FileFS(nonSeekableFS{fsys}, "js/a.js"))
+	suite.Equal(http.StatusNotModified, response.responseWriter.(*httptest.ResponseRecorder).Code)
+
+	// File doesn't exist
+	response = newResponse(httptest.NewRecorder(), httptest.NewRequest("GET", "/test-route", nil))
+	err = response.FileFS(fsys, "css")
+	suite.Equal("open css: no such file or directory", err.Error())
+	suite.Equal(404, response.status)
+	suite.True(response.empty)
+}
+
 func (suite *ResponseTestSuite) TestResponseDownload() {
 	size := suite.getFileSize("config/config.test.json")
 	rawRequest := httptest.NewRequest("GET", "/test-route", strings.NewReader("body"))
@@ -3,8 +3,11 @@ package goyave
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
+	"goyave.dev/goyave/v3/config"
 	"goyave.dev/goyave/v3/validation"
 )
 
@@ -16,6 +19,10 @@ type Route struct {
 	parent          *Router
 	handler         Handler
 	validationRules *validation.Rules
+	timeout         time.Duration
+	host            *hostPattern
+	bindings        []*binding
+	excluded        []Middleware
 	middlewareHolder
 	parameterizable
 }
@@ -36,10 +43,16 @@ func newRoute(handler Handler) *Route {
 }
 
 func (r *Route) match(req *http.Request, match *routeMatch) bool {
-	if params := r.parameterizable.regex.FindStringSubmatch(match.currentPath); params != nil {
+	if !r.host.matchRequest(req, match) {
+		if match.err == nil {
+			match.err = errMatchNotFound
+		}
+		return false
+	}
+	if _, values, ok := r.matchPath(match.currentPath, false); ok {
 		if r.checkMethod(req.Method) {
-			if len(params) > 1 {
-				match.mergeParams(r.makeParameters(params))
+			if len(values) > 0 {
+				match.mergeParams(r.makeParameters(values))
 			}
 			match.route = r
 			return true
@@ -67,11 +80,79 @@ func (r *Route) checkMethod(method string) bool {
 	return false
 }
 
-func (r *Route) makeParameters(match []string) map[string]string {
-	return r.parameterizable.makeParameters(match, r.parameters)
+func (r *Route) makeParameters(values []string) map[string]string {
+	return r.parameterizable.makeParameters(values, r.parameters)
+}
+
+// Timeout set the maximum duration of the execution of this route's handler,
+// including its middleware. It overrides the timeout of the parent routers.
+// A negative duration disables the timeout inherited from the parent routers,
+// which is useful for long-lived connections such as websockets or event streams.
+//
+// The request context carries the corresponding deadline, so it can be passed to
+// the database or to outgoing requests. If the deadline is reached before the handler
+// returns, the status handler for "503 Service Unavailable" is executed and any
+// further write from the handler is discarded. The handler is not interrupted
+// though: it should stop when the request context is done.
+//
+// Keep in mind that the "server.timeout" config entry still applies to the
+// connection, so it should be greater than the timeouts of all routes.
+//
+// Returns itself.
+func (r *Route) Timeout(timeout time.Duration) *Route {
+	r.timeout = timeout
+	return r
+}
+
+// GetTimeout returns the timeout of this route, or the one inherited
+// from its parent routers. Returns 0 if there is no timeout.
+func (r *Route) GetTimeout() time.Duration {
+	timeout := r.timeout
+	for parent := r.parent; timeout == 0 && parent != nil; parent = parent.parent {
+		timeout = parent.timeout
+	}
+	if timeout < 0 {
+		return 0
+	}
+	return timeout
+}
+
+// Host restrict this route to the requests whose host matches the given
+// pattern, in addition to the host pattern of its parent routers.
+// See "Router.Host" for more details.
+//
+// Returns itself.
+func (r *Route) Host(pattern string) *Route {
+	r.host = compileHostPattern(pattern, r.parent.regexCache)
+	return r
+}
+
+// GetHost returns the host pattern of this route, or the one inherited from
+// its closest parent router. Returns an empty string if the route matches any host.
+func (r *Route) GetHost() string {
+	if host := r.getHostPattern(); host != nil {
+		return host.pattern
+	}
+	return ""
 }
 
-// Name set the name of the route.
+func (r *Route) getHostPattern() *hostPattern {
+	if r.host != nil {
+		return r.host
+	}
+	for parent := r.parent; parent != nil; parent = parent.parent {
+		if parent.host != nil {
+			return parent.host
+		}
+	}
+	return nil
+}
+
+// Name set the name of the route. The name is prefixed with the name
+// prefix of the parent routers (see "Router.NamePrefix").
+// Named routes can be retrieved from any router of the same tree,
+// or using "goyave.GetRoute()".
+//
 // Panics if a route with the same name already exists.
 // Returns itself.
 func (r *Route) Name(name string) *Route {
@@ -79,8 +160,9 @@ func (r *Route) Name(name string) *Route {
 		panic(fmt.Errorf("Route name is already set"))
 	}
 
-	if _, ok := r.parent.namedRoutes[name]; ok {
-		panic(fmt.Errorf("Route %q already exists", name))
+	name = r.parent.getFullNamePrefix() + name
+	if existing, ok := r.parent.namedRoutes[name]; ok {
+		panic(fmt.Errorf("Route %q already exists (%s)", name, existing.GetFullURI()))
 	}
 
 	r.name = name
@@ -106,11 +188,45 @@ func (r *Route) Middleware(middleware ...Middleware) *Route {
 	return r
 }
 
+// WithoutMiddleware exclude middleware inherited from the parent routers for
+// this route only.
+//
+// **Middleware are matched by func value: the excluded middleware must be the
+// value given to "Middleware()". Calling a middleware factory such as
+// "middleware.Compress()" again creates a different middleware that is not
+// excluded. Method values such as "obj.Handle" never match.**
+// See "Router.WithoutMiddleware" for more details.
+//
+//  router.Middleware(authenticator)
+//  router.Post("/login", auth.Login).WithoutMiddleware(authenticator)
+//
+// Returns itself.
+func (r *Route) WithoutMiddleware(middleware ...Middleware) *Route {
+	r.excluded = append(r.excluded, middleware...)
+	return r
+}
+
 // BuildURL build a full URL pointing to this route.
+// If the route has a host pattern (see "GetHost"), the URL uses it
+// instead of the application's domain. The values of the host parameters
+// come first, followed by the values of the URI parameters.
+//  router.Group().Host("{tenant}.example.com").Get("/users/{id}", user.Show)
+//  route.BuildURL("acme", "42") // "http://acme.example.com/users/42"
+//
 // Panics if the amount of parameters doesn't match the amount of
 // actual parameters for this route.
 func (r *Route) BuildURL(parameters ...string) string {
-	return BaseURL() + r.BuildURI(parameters...)
+	host := r.getHostPattern()
+	if host == nil {
+		return BaseURL() + r.BuildURI(parameters...)
+	}
+
+	count := len(host.parameters)
+	if len(parameters) < count {
+		panic(fmt.Errorf("BuildURL: route has %d host parameters, %d given", count, len(parameters)))
+	}
+	protocol := config.GetString("server.protocol")
+	return getAddressForHost(protocol, host.build(parameters[:count])) + r.BuildURI(parameters[count:]...)
 }
 
 // BuildURI build a full URI pointing to this route. The returned
@@ -124,27 +240,76 @@ func (r *Route) BuildURI(parameters ...string) string {
 		panic(fmt.Errorf("BuildURI: route has %d parameters, %d given", len(fullParameters), len(parameters)))
 	}
 
-	var builder strings.Builder
-	builder.Grow(len(fullURI))
+	return r.fill(fullURI, parameters)
+}
 
-	idxs, _ := r.braceIndices(fullURI)
-	length := len(idxs)
-	end := 0
-	currentParam := 0
-	for i := 0; i < length; i += 2 {
-		raw := fullURI[end:idxs[i]]
-		end = idxs[i+1]
-		builder.WriteString(raw)
-		builder.WriteString(parameters[currentParam])
-		currentParam++
-		end++ // Skip closing braces
+// BuildURIFromMap build a full URI pointing to this route, filling the
+// parameters by name. The entries not matching any parameter are
+// appended as an encoded query string.
+//  route := router.Get("/users/{id}/posts", post.Index)
+//  route.BuildURIFromMap(map[string]string{"id": "42", "page": "2"})
+//  // "/users/42/posts?page=2"
+//
+// Panics if a parameter of this route is missing.
+func (r *Route) BuildURIFromMap(parameters map[string]string) string {
+	fullURI, fullParameters := r.GetFullURIAndParameters()
+	used := make(map[string]bool, len(fullParameters))
+	uri := r.fill(fullURI, r.parameterValues("BuildURIFromMap", fullParameters, parameters, used))
+	return uri + buildQuery(parameters, used)
+}
+
+// BuildURLFromMap build a full URL pointing to this route, filling the
+// parameters, including host parameters, by name. The entries not matching
+// any parameter are appended as an encoded query string.
+// See "BuildURL" and "BuildURIFromMap" for more details.
+//
+// Panics if a parameter of this route is missing.
+func (r *Route) BuildURLFromMap(parameters map[string]string) string {
+	fullURI, fullParameters := r.GetFullURIAndParameters()
+	used := make(map[string]bool, len(fullParameters))
+	uri := r.fill(fullURI, r.parameterValues("BuildURLFromMap", fullParameters, parameters, used))
+
+	base := BaseURL()
+	if host := r.getHostPattern(); host != nil {
+		values := r.parameterValues("BuildURLFromMap", host.parameters, parameters, used)
+		base = getAddressForHost(config.GetString("server.protocol"), host.build(values))
 	}
-	builder.WriteString(fullURI[end:])
+	return base + uri + buildQuery(parameters, used)
+}
 
-	return builder.String()
+// parameterValues returns the values of the given parameters, in order,
+// and marks them as used.
+func (r *Route) parameterValues(caller string, names []string, parameters map[string]string, used map[string]bool) []string {
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		value, ok := parameters[name]
+		if !ok {
+			panic(fmt.Errorf("%s: missing value for parameter %q", caller, name))
+		}
+		values = append(values, value)
+		used[name] = true
+	}
+	return values
+}
+
+// buildQuery returns an encoded query string, starting with "?", from the
+// given parameters that are not used. Returns an empty string if all the
+// parameters are used.
+func buildQuery(parameters map[string]string, used map[string]bool) string {
+	query := url.Values{}
+	for k, v := range parameters {
+		if !used[k] {
+			query.Set(k, v)
+		}
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
 }
 
-// GetName get the name of this route.
+// GetName get the name of this route, including the name prefix
+// of its parent routers.
 func (r *Route) GetName() string {
 	return r.name
 }
@@ -22,12 +22,12 @@ func (suite *RouteTestSuite) TestNewRoute() {
 func (suite *RouteTestSuite) TestMakeParameters() {
 	regexCache := make(map[string]*regexp.Regexp, 5)
 	route := newRoute(func(resp *Response, r *Request) {})
-	route.compileParameters("/product/{id:[0-9]+}", true, regexCache)
+	route.compileParameters("/product/{id:[0-9]+}", regexCache)
 	suite.Equal([]string{"id"}, route.parameters)
-	suite.NotNil(route.regex)
-	suite.True(route.regex.MatchString("/product/666"))
-	suite.False(route.regex.MatchString("/product/"))
-	suite.False(route.regex.MatchString("/product/qwerty"))
+	suite.NotEmpty(route.segments)
+	suite.True(route.matches("/product/666", false))
+	suite.False(route.matches("/product/", false))
+	suite.False(route.matches("/product/qwerty", false))
 }
 
 func (suite *RouteTestSuite) TestMatch() {
@@ -43,7 +43,7 @@ func (suite *RouteTestSuite) TestMatch() {
 		handler:         handler,
 		validationRules: nil,
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 
 	rawRequest := httptest.NewRequest("GET", "/product/33", nil)
 	match := routeMatch{currentPath: rawRequest.URL.Path}
@@ -77,7 +77,7 @@ func (suite *RouteTestSuite) TestMatch() {
 		handler:         handler,
 		validationRules: nil,
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	rawRequest = httptest.NewRequest("GET", "/product/666/test", nil)
 	match = routeMatch{currentPath: rawRequest.URL.Path}
 	suite.True(route.match(rawRequest, &match))
@@ -92,7 +92,7 @@ func (suite *RouteTestSuite) TestMatch() {
 		handler:         handler,
 		validationRules: nil,
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	rawRequest = httptest.NewRequest("GET", "/categories/lawn-mower/asc", nil)
 	match = routeMatch{currentPath: rawRequest.URL.Path}
 	suite.True(route.match(rawRequest, &match))
@@ -154,7 +154,7 @@ func (suite *RouteTestSuite) TestBuildURI() {
 		uri:     "/product/{id:[0-9+]}",
 		methods: []string{"GET", "POST"},
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	suite.Equal("/product/42", route.BuildURI("42"))
 
 	suite.Panics(func() {
@@ -169,7 +169,7 @@ func (suite *RouteTestSuite) TestBuildURI() {
 		uri:     "/product/{id:[0-9+]}/{name}/accessories",
 		methods: []string{"GET", "POST"},
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	suite.Equal("/product/42/screwdriver/accessories", route.BuildURI("42", "screwdriver"))
 
 	router := NewRouter().Subrouter("/product").Subrouter("/{id:[0-9+]}")
@@ -185,7 +185,7 @@ func (suite *RouteTestSuite) TestBuildURL() {
 		uri:     "/product/{id:[0-9+]}",
 		methods: []string{"GET", "POST"},
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	suite.Equal("http://127.0.0.1:1235/product/42", route.BuildURL("42"))
 
 	suite.Panics(func() {
@@ -200,7 +200,7 @@ func (suite *RouteTestSuite) TestBuildURL() {
 		uri:     "/product/{id:[0-9+]}/{name}/accessories",
 		methods: []string{"GET", "POST"},
 	}
-	route.compileParameters(route.uri, true, regexCache)
+	route.compileParameters(route.uri, regexCache)
 	suite.Equal("http://127.0.0.1:1235/product/42/screwdriver/accessories", route.BuildURL("42", "screwdriver"))
 
 	router := NewRouter().Subrouter("/product").Subrouter("/{id:[0-9+]}")
@@ -209,6 +209,49 @@ func (suite *RouteTestSuite) TestBuildURL() {
 	suite.Equal("http://127.0.0.1:1235/product/42/screwdriver/accessories", route.BuildURL("42", "screwdriver"))
 }
 
+func (suite *RouteTestSuite) TestBuildURIFromMap() {
+	router := NewRouter().Subrouter("/product").Subrouter("/{id:[0-9+]}")
+	route := router.Route("GET|POST", "/{name}/accessories", func(resp *Response, r *Request) {})
+
+	suite.Equal("/product/42/screwdriver/accessories", route.BuildURIFromMap(map[string]string{"id": "42", "name": "screwdriver"}))
+	suite.Equal(
+		"/product/42/screwdriver/accessories?page=2&q=flat+head",
+		route.BuildURIFromMap(map[string]string{"id": "42", "name": "screwdriver", "page": "2", "q": "flat head"}),
+	)
+
+	suite.Panics(func() {
+		route.BuildURIFromMap(map[string]string{"id": "42"})
+	})
+	suite.Panics(func() {
+		route.BuildURIFromMap(nil)
+	})
+
+	route = router.Get("/", func(resp *Response, r *Request) {})
+	suite.Equal("/product/42?sort=price", route.BuildURIFromMap(map[string]string{"id": "42", "sort": "price"}))
+}
+
+func (suite *RouteTestSuite) TestBuildURLFromMap() {
+	router := NewRouter()
+	route := router.Get("/product/{id}", func(resp *Response, r *Request) {})
+	suite.Equal("http://127.0.0.1:1235/product/42", route.BuildURLFromMap(map[string]string{"id": "42"}))
+	suite.Equal("http://127.0.0.1:1235/product/42?page=2", route.BuildURLFromMap(map[string]string{"id": "42", "page": "2"}))
+
+	tenant := router.Group()
+	tenant.Host("{tenant}.example.com")
+	route = tenant.Get("/users/{id}", func(resp *Response, r *Request) {})
+	suite.Equal(
+		"http://acme.example.com:1235/users/42?tab=posts",
+		route.BuildURLFromMap(map[string]string{"tenant": "acme", "id": "42", "tab": "posts"}),
+	)
+
+	suite.Panics(func() {
+		route.BuildURLFromMap(map[string]string{"id": "42"})
+	})
+	suite.Panics(func() {
+		route.BuildURLFromMap(map[string]string{"tenant": "acme"})
+	})
+}
+
 func (suite *RouteTestSuite) TestValidate() {
 	route := &Route{
 		name:    "route-name",
@@ -1,14 +1,20 @@
 package goyave
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"io/fs"
 	"net/http"
 	"os"
 	"regexp"
 	"strings"
+	"time"
+	"unsafe"
 
+	"goyave.dev/goyave/v3/config"
 	"goyave.dev/goyave/v3/cors"
-	"goyave.dev/goyave/v3/helper/filesystem"
+	"goyave.dev/goyave/v3/helper"
 )
 
 type routeMatcher interface {
@@ -26,9 +32,14 @@ type Router struct {
 	parameterizable
 	middlewareHolder
 
+	tree              *routeTree
+	host              *hostPattern
 	prefix            string
+	namePrefix        string
+	excluded          []Middleware
 	routes            []*Route
 	subrouters        []*Router
+	timeout           time.Duration
 	hasCORSMiddleware bool
 }
 
@@ -43,10 +54,11 @@ type middlewareHolder struct {
 }
 
 type routeMatch struct {
-	route       *Route
-	parameters  map[string]string
-	err         error
-	currentPath string
+	route          *Route
+	parameters     map[string]string
+	err            error
+	currentPath    string
+	allowedMethods []string
 }
 
 var (
@@ -59,10 +71,14 @@ var (
 	notFoundRoute = newRoute(func(response *Response, request *Request) {
 		response.Status(http.StatusNotFound)
 	})
+	optionsRoute = newRoute(func(response *Response, request *Request) {
+		response.Status(http.StatusNoContent)
+	})
 )
 
 func init() {
 	methodNotAllowedRoute.name = "method-not-allowed"
+	optionsRoute.name = "options"
 }
 
 // PanicStatusHandler for the HTTP 500 error.
@@ -91,9 +107,45 @@ func ErrorStatusHandler(response *Response, request *Request) {
 
 // ValidationStatusHandler for HTTP 400 and HTTP 422 errors.
 // Writes the validation errors to the response.
+//
+// The format of the response is defined by the "server.validationErrorFormat"
+// config entry:
+//   - "default": the flat "validation.Errors" in the "validationError" field.
+//   - "detailed": the structured "validation.ErrorDetails" in the "validationError" field.
+//     Each error contains the name and the parameters of the rule that didn't pass.
+//   - "problem": a RFC 7807 "application/problem+json" document. The structured
+//     "validation.ErrorDetails" are in the "errors" field.
 func ValidationStatusHandler(response *Response, request *Request) {
-	message := map[string]interface{}{"validationError": response.GetError()}
-	response.JSON(response.GetStatus(), message)
+	var errors interface{} = response.GetError()
+	if details := request.ValidationErrorDetails(); details != nil {
+		errors = details
+	}
+
+	switch config.GetString("server.validationErrorFormat") {
+	case "detailed":
+		response.JSON(response.GetStatus(), map[string]interface{}{"validationError": errors})
+	case "problem":
+		response.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+		json.NewEncoder(response).Encode(&problem{
+			Type:     "about:blank",
+			Title:    http.StatusText(response.GetStatus()),
+			Status:   response.GetStatus(),
+			Instance: request.URI().Path,
+			Errors:   errors,
+		})
+	default:
+		message := map[string]interface{}{"validationError": response.GetError()}
+		response.JSON(response.GetStatus(), message)
+	}
+}
+
+// problem a RFC 7807 problem details document.
+type problem struct {
+	Type     string      `json:"type"`
+	Title    string      `json:"title"`
+	Status   int         `json:"status"`
+	Instance string      `json:"instance,omitempty"`
+	Errors   interface{} `json:"errors,omitempty"`
 }
 
 // NewRouter create a new root-level Router that is pre-configured with core
@@ -115,6 +167,7 @@ func NewRouter() *Router {
 			middleware: make([]Middleware, 0, 3),
 		},
 		regexCache: make(map[string]*regexp.Regexp, 5),
+		tree:       newRouteTree(),
 	}
 	router.StatusHandler(PanicStatusHandler, http.StatusInternalServerError)
 	router.StatusHandler(ValidationStatusHandler, http.StatusBadRequest, http.StatusUnprocessableEntity)
@@ -175,54 +228,124 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 
 func (r *Router) match(req *http.Request, match *routeMatch) bool {
 	// Check if router itself matches
-	var params []string
-	if r.parameterizable.regex != nil {
-		params = r.parameterizable.regex.FindStringSubmatch(match.currentPath)
-	} else {
-		params = []string{""}
+	if !r.host.matchRequest(req, match) {
+		match.route = notFoundRoute
+		return false
 	}
-
-	if params != nil {
-		match.trimCurrentPath(params[0])
-		if len(params) > 1 {
-			match.mergeParams(r.makeParameters(params))
+	if length, values, ok := r.matchPath(match.currentPath, true); ok {
+		match.trimCurrentPath(match.currentPath[:length])
+		if len(values) > 0 {
+			match.mergeParams(r.makeParameters(values))
 		}
+		return r.matchTree(req, match)
+	}
 
-		// Check in subrouters first
-		for _, router := range r.subrouters {
-			if router.match(req, match) {
-				if router.prefix == "" && match.route == methodNotAllowedRoute {
-					// This allows route groups with subrouters having empty prefix.
-					break
-				}
-				return true
+	match.route = notFoundRoute
+	return false
+}
+
+// matchTree finds the route matching the current path among the routes
+// and subrouters of this router. The router's own prefix is expected to be
+// already trimmed from the current path.
+func (r *Router) matchTree(req *http.Request, match *routeMatch) bool {
+	lookup := r.tree.lookup(match.currentPath)
+	path := match.currentPath
+	shadowed := false
+	methodNotAllowed := false
+
+	// Check in subrouters first
+	for _, m := range lookup.routers {
+		router := m.router
+		if !router.host.matchRequest(req, match) {
+			continue
+		}
+		match.currentPath = path
+		match.trimCurrentPath(path[:m.length])
+		if len(m.values) > 0 {
+			match.mergeParams(router.makeParameters(m.values))
+		}
+		if router.matchTree(req, match) {
+			if router.prefix == "" && router.host == nil && (match.route == methodNotAllowedRoute || match.route == optionsRoute) {
+				// This allows route groups with subrouters having empty prefix.
+				// The other groups may share the path, keep looking for
+				// the requested method and collect the allowed methods.
+				methodNotAllowed = true
+				continue
 			}
+			return true
+		}
+		if router.prefix != "" || router.host != nil {
+			// A subrouter's prefix or host matched: routes of this
+			// router sharing the same prefix are not reachable.
+			shadowed = true
 		}
+	}
+	match.currentPath = path
+	if methodNotAllowed {
+		match.err = errMatchMethodNotAllowed
+	}
 
-		// Check if any route matches
-		for _, route := range r.routes {
-			if route.match(req, match) {
+	// Check if any route matches
+	if !shadowed {
+		for _, m := range lookup.routes {
+			if !m.route.host.matchRequest(req, match) {
+				continue
+			}
+			if m.route.checkMethod(req.Method) {
+				if len(m.values) > 0 {
+					match.mergeParams(m.route.makeParameters(m.values))
+				}
+				match.route = m.route
 				return true
 			}
+			match.err = errMatchMethodNotAllowed
+			match.addAllowedMethods(m.route.methods)
 		}
 	}
 
 	if match.err == errMatchMethodNotAllowed {
-		match.route = methodNotAllowedRoute
+		if req.Method == http.MethodOptions {
+			match.route = optionsRoute
+		} else {
+			match.route = methodNotAllowedRoute
+		}
 		return true
 	}
 
+	match.err = errMatchNotFound
 	match.route = notFoundRoute
 	return false
 }
 
-func (r *Router) makeParameters(match []string) map[string]string {
-	return r.parameterizable.makeParameters(match, r.parameters)
+func (r *Router) makeParameters(values []string) map[string]string {
+	return r.parameterizable.makeParameters(values, r.parameters)
+}
+
+// getFullPrefix returns the prefix of this router and all its parents.
+func (r *Router) getFullPrefix() string {
+	prefix := ""
+	for router := r; router != nil; router = router.parent {
+		prefix = router.prefix + prefix
+	}
+	return prefix
+}
+
+// getFullNamePrefix returns the name prefix of this router and all its parents.
+func (r *Router) getFullNamePrefix() string {
+	prefix := ""
+	for router := r; router != nil; router = router.parent {
+		prefix = router.namePrefix + prefix
+	}
+	return prefix
 }
 
 // Subrouter create a new sub-router from this router.
 // Use subrouters to create route groups and to apply middleware to multiple routes.
 // CORS options are also inherited.
+//
+// Subrouters are matched before the routes of their parent, in registration order.
+// If a subrouter's prefix matches but none of its routes do, the routes of the
+// parent router are not checked, unless the subrouter has an empty prefix.
 func (r *Router) Subrouter(prefix string) *Router {
 	if prefix == "/" {
 		prefix = ""
@@ -240,12 +363,81 @@ func (r *Router) Subrouter(prefix string) *Router {
 			middleware: nil,
 		},
 		regexCache: r.regexCache,
+		tree:       newRouteTree(),
 	}
-	router.compileParameters(router.prefix, false, r.regexCache)
+	router.compileParameters(router.prefix, r.regexCache)
+	r.tree.insertRouter(router, len(r.subrouters))
 	r.subrouters = append(r.subrouters, router)
 	return router
 }
 
+// Host restrict this router to the requests whose host matches the given
+// pattern. The pattern supports parameters using the same syntax as URIs.
+// Unconstrained parameters match one or more characters up to the next dot.
+// Host parameters are available in "Request.Params", like URI parameters.
+// The port of the request's host is ignored, and it is compared in lowercase.
+// The static parts of the pattern are lowercased too, but parameter names are
+// kept as written.
+//
+//	admin := router.Group()
+//	admin.Host("admin.example.com")
+//
+//	tenant := router.Group()
+//	tenant.Host("{tenant}.example.com")
+//
+// Subrouters are matched in registration order, so routers with a static host
+// should be registered before routers with a parameterized host. If a subrouter's
+// host matches but none of its routes do, the routes of the parent router are
+// not checked.
+//
+// Subrouters inherit the host constraint of their parent.
+func (r *Router) Host(pattern string) {
+	r.host = compileHostPattern(pattern, r.regexCache)
+}
+
+// GetHost returns the host pattern of this router, or an empty string
+// if it matches any host.
+func (r *Router) GetHost() string {
+	if r.host == nil {
+		return ""
+	}
+	return r.host.pattern
+}
+
+// WithoutMiddleware exclude middleware inherited from the parent routers for
+// the routes of this router and its subrouters. The middleware of this router
+// are not affected.
+//
+// **Middleware are matched by func value: the excluded middleware must be the
+// value given to "Middleware()". Calling a middleware factory such as
+// "auth.Middleware()" again, or evaluating a method value again, creates a
+// different middleware that is not excluded. Keep the middleware in a variable.
+// Method values such as "obj.Handle" are new func values every time they are
+// evaluated: "WithoutMiddleware(obj.Handle)" never matches "Middleware(obj.Handle)".**
+//
+//  authenticator := auth.Middleware(&model.User{}, &auth.JWTAuthenticator{})
+//  api := router.Subrouter("/api")
+//  api.Middleware(authenticator)
+//
+//  public := api.Subrouter("/public")
+//  public.WithoutMiddleware(authenticator)
+func (r *Router) WithoutMiddleware(middleware ...Middleware) {
+	r.excluded = append(r.excluded, middleware...)
+}
+
+// NamePrefix set a prefix prepended to the name of the routes of this router
+// and its subrouters. The prefixes of the parent routers are prepended too.
+// Only the routes named after calling this method are affected.
+//
+//	admin := router.Subrouter("/admin")
+//	admin.NamePrefix("admin.")
+//	users := admin.Subrouter("/users")
+//	users.NamePrefix("users.")
+//	users.Get("/", user.Index).Name("index") // "admin.users.index"
+func (r *Router) NamePrefix(prefix string) {
+	r.namePrefix = prefix
+}
+
 // Group create a new sub-router with an empty prefix.
 func (r *Router) Group() *Router {
 	return r.Subrouter("")
@@ -262,7 +454,8 @@ func (r *Router) Middleware(middleware ...Middleware) {
 // Route register a new route.
 //
 // Multiple methods can be passed using a pipe-separated string.
-//  "PUT|PATCH"
+//
+//	"PUT|PATCH"
 //
 // The validation rules set is optional. If you don't want your route
 // to be validated, pass "nil".
@@ -273,6 +466,12 @@ func (r *Router) Middleware(middleware ...Middleware) {
 // If the router has CORS options set, the "OPTIONS" method is automatically added
 // to the matcher if it's missing, so it allows preflight requests.
 //
+// If a request matches the URI of one or more routes but none of their methods,
+// the router responds with "405 Method Not Allowed" and an "Allow" header listing
+// the methods registered for this URI. "OPTIONS" requests are answered automatically
+// with "204 No Content" and the same "Allow" header. Both responses can be customized
+// using status handlers.
+//
 // Returns the generated route.
 func (r *Router) Route(methods string, uri string, handler Handler) *Route {
 	return r.registerRoute(methods, uri, handler)
@@ -287,7 +486,7 @@ func (r *Router) registerRoute(methods string, uri string, handler Handler) *Rou
 		methods += "|HEAD"
 	}
 
-	if uri == "/" && r.parent != nil {
+	if uri == "/" && r.getFullPrefix() != "" {
 		uri = ""
 	}
 
@@ -298,7 +497,8 @@ func (r *Router) registerRoute(methods string, uri string, handler Handler) *Rou
 		parent:  r,
 		handler: handler,
 	}
-	route.compileParameters(route.uri, true, r.regexCache)
+	route.compileParameters(route.uri, r.regexCache)
+	r.tree.insertRoute(route, len(r.routes))
 	r.routes = append(r.routes, route)
 	return route
 }
@@ -333,7 +533,8 @@ func (r *Router) Options(uri string, handler Handler) *Route {
 	return r.registerRoute(http.MethodOptions, uri, handler)
 }
 
-// GetRoute get a named route.
+// GetRoute get a named route. Named routes are shared by all the routers
+// of the same tree, so the route can belong to a parent router or a subrouter.
 // Returns nil if the route doesn't exist.
 func (r *Router) GetRoute(name string) *Route {
 	return r.namedRoutes[name]
@@ -345,8 +546,49 @@ func (r *Router) GetRoute(name string) *Route {
 //
 // If no file is given in the url, or if the given file is a directory, the handler will
 // send the "index.html" file if it exists.
+//
+// See "StaticWithOptions" for caching, precompressed files, single-page
+// applications and directory listings.
 func (r *Router) Static(uri string, directory string, download bool, middleware ...Middleware) {
-	r.registerRoute(http.MethodGet, uri+"{resource:.*}", staticHandler(directory, download)).Middleware(middleware...)
+	r.StaticFS(uri, os.DirFS(directory), download, middleware...)
+}
+
+// StaticFS works like "Static" but serves the files of the given filesystem,
+// for example files embedded in the binary with "embed.FS". Use "fs.Sub" to
+// serve a subdirectory.
+//
+//	//go:embed resources/public
+//	var public embed.FS
+//
+//	sub, _ := fs.Sub(public, "resources/public")
+//	router.StaticFS("/public", sub, false)
+func (r *Router) StaticFS(uri string, fsys fs.FS, download bool, middleware ...Middleware) {
+	r.StaticWithOptions(uri, fsys, &StaticOptions{Download: download}, middleware...)
+}
+
+// StaticWithOptions works like "StaticFS" with additional options. Use "os.DirFS"
+// to serve a directory of the OS filesystem. The options can be nil.
+//
+//	router.StaticWithOptions("/", os.DirFS("public"), &goyave.StaticOptions{
+//	  Fingerprint:   goyave.DefaultFingerprintPattern,
+//	  CacheControl:  "no-cache",
+//	  Precompressed: true,
+//	  Fallback:      "index.html",
+//	})
+func (r *Router) StaticWithOptions(uri string, fsys fs.FS, options *StaticOptions, middleware ...Middleware) {
+	if options == nil {
+		options = &StaticOptions{}
+	}
+	r.registerRoute(http.MethodGet, uri+"{resource:.*}", staticHandler(fsys, options)).Middleware(middleware...)
+}
+
+// Timeout set the default timeout of the routes of this router and its subrouters.
+// Routes and subrouters can override it. See "Route.Timeout" for more details.
+//
+//	router.Timeout(2 * time.Second)
+//	router.Get("/reports", report.Index).Timeout(60 * time.Second)
+func (r *Router) Timeout(timeout time.Duration) {
+	r.timeout = timeout
 }
 
 // CORS set the CORS options for this route group.
@@ -378,36 +620,6 @@ func (r *Router) StatusHandler(handler Handler, status int, additionalStatuses .
 	}
 }
 
-func staticHandler(directory string, download bool) Handler {
-	return func(response *Response, r *Request) {
-		file := r.Params["resource"]
-		path := cleanStaticPath(directory, file)
-
-		var err error
-		if download {
-			err = response.Download(path, file[strings.LastIndex(file, "/")+1:])
-		} else {
-			err = response.File(path)
-		}
-
-		if _, ok := err.(*os.PathError); err != nil && !ok {
-			ErrLogger.Println(err)
-		}
-	}
-}
-
-func cleanStaticPath(directory string, file string) string {
-	file = strings.TrimPrefix(file, "/")
-	path := directory + "/" + file
-	if filesystem.IsDirectory(path) {
-		if !strings.HasSuffix(path, "/") {
-			path += "/"
-		}
-		path += "index.html"
-	}
-	return path
-}
-
 func (r *Router) copyStatusHandlers() map[int]Handler {
 	cpy := make(map[int]Handler, len(r.statusHandlers))
 	for key, value := range r.statusHandlers {
@@ -417,6 +629,16 @@ func (r *Router) copyStatusHandlers() map[int]Handler {
 }
 
 func (r *Router) requestHandler(match *routeMatch, w http.ResponseWriter, rawRequest *http.Request) {
+	var tw *timeoutWriter
+	timeout := match.route.GetTimeout()
+	if timeout > 0 {
+		ctx, cancel := context.WithTimeout(rawRequest.Context(), timeout)
+		defer cancel()
+		rawRequest = rawRequest.WithContext(ctx)
+		tw = newTimeoutWriter(w)
+		w = tw
+	}
+
 	request := &Request{
 		httpRequest: rawRequest,
 		route:       match.route,
@@ -426,6 +648,9 @@ func (r *Router) requestHandler(match *routeMatch, w http.ResponseWriter, rawReq
 		Extra:       map[string]interface{}{},
 	}
 	response := newResponse(w, rawRequest)
+	if match.route == methodNotAllowedRoute || match.route == optionsRoute {
+		response.Header().Set("Allow", strings.Join(match.getAllowedMethods(), ", "))
+	}
 	handler := match.route.handler
 
 	// Validate last.
@@ -436,12 +661,27 @@ func (r *Router) requestHandler(match *routeMatch, w http.ResponseWriter, rawReq
 	// Route-specific middleware is executed after router middleware
 	handler = match.route.applyMiddleware(handler)
 
+	// Bound models are loaded before route-specific middleware
+	if len(match.route.bindings) > 0 {
+		handler = bindMiddleware(match.route.bindings)(handler)
+	}
+
+	// Exclusions apply to the middleware inherited from the parent routers
+	excluded := match.route.excluded
 	parent := match.route.parent
 	for parent != nil {
-		handler = parent.applyMiddleware(handler)
+		handler = parent.applyMiddlewareExcept(handler, excluded)
+		if len(parent.excluded) > 0 {
+			excluded = append(excluded[:len(excluded):len(excluded)], parent.excluded...)
+		}
 		parent = parent.parent
 	}
 
+	if tw != nil {
+		r.runWithTimeout(rawRequest.Context(), tw, handler, response, request)
+		return
+	}
+
 	handler(response, request)
 
 	r.finalize(response, request)
@@ -461,11 +701,17 @@ func (r *Router) finalize(response *Response, request *Request) {
 		}
 	}
 
-	if !response.wroteHeader && !response.hijacked {
+	if !response.wroteHeader && !response.hijacked && response.headerDeferred == 0 {
 		response.WriteHeader(response.status)
 	}
 
 	response.close()
+
+	if !response.wroteHeader && !response.hijacked {
+		// Header deferred by a chained writer which didn't write it when closed
+		response.headerDeferred = 0
+		response.WriteHeader(response.status)
+	}
 }
 
 func (h *middlewareHolder) applyMiddleware(handler Handler) Handler {
@@ -475,6 +721,43 @@ func (h *middlewareHolder) applyMiddleware(handler Handler) Handler {
 	return handler
 }
 
+// applyMiddlewareExcept works like "applyMiddleware" but skips the middleware
+// present in the given list.
+func (h *middlewareHolder) applyMiddlewareExcept(handler Handler, excluded []Middleware) Handler {
+	if len(excluded) == 0 {
+		return h.applyMiddleware(handler)
+	}
+	for i := len(h.middleware) - 1; i >= 0; i-- {
+		if !containsMiddleware(excluded, h.middleware[i]) {
+			handler = h.middleware[i](handler)
+		}
+	}
+	return handler
+}
+
+// middlewareIdentity returns the identity of the given middleware func value.
+// Functions can't be compared in Go, but a func value is a pointer to the
+// function's closure. Closures are allocated when they are created, so two
+// closures returned by the same function don't share the same identity.
+// Functions declared at package level and closures that don't capture
+// variables are statically allocated and always share the same identity.
+//
+// The identity must only be used for immediate comparisons: a uintptr doesn't
+// keep the closure referenced, so its address may be reused once collected.
+func middlewareIdentity(middleware Middleware) uintptr {
+	return *(*uintptr)(unsafe.Pointer(&middleware))
+}
+
+func containsMiddleware(list []Middleware, middleware Middleware) bool {
+	id := middlewareIdentity(middleware)
+	for _, m := range list {
+		if middlewareIdentity(m) == id {
+			return true
+		}
+	}
+	return false
+}
+
 func (rm *routeMatch) mergeParams(params map[string]string) {
 	if rm.parameters == nil {
 		rm.parameters = params
@@ -484,6 +767,24 @@ func (rm *routeMatch) mergeParams(params map[string]string) {
 	}
 }
 
+func (rm *routeMatch) addAllowedMethods(methods []string) {
+	for _, method := range methods {
+		if !helper.ContainsStr(rm.allowedMethods, method) {
+			rm.allowedMethods = append(rm.allowedMethods, method)
+		}
+	}
+}
+
+// getAllowedMethods returns the methods supported by the matched path.
+// "OPTIONS" is always supported, either because a route matches it or because
+// the router answers it automatically.
+func (rm *routeMatch) getAllowedMethods() []string {
+	if !helper.ContainsStr(rm.allowedMethods, http.MethodOptions) {
+		return append(rm.allowedMethods, http.MethodOptions)
+	}
+	return rm.allowedMethods
+}
+
 func (rm *routeMatch) trimCurrentPath(fullMatch string) {
 	rm.currentPath = rm.currentPath[len(fullMatch):]
 }
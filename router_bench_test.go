@@ -1,9 +1,11 @@
 package goyave
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"runtime"
+	"strings"
 	"testing"
 
 	"goyave.dev/goyave/v3/validation"
@@ -226,6 +228,60 @@ func BenchmarkMatchAll(b *testing.B) {
 	}
 }
 
+func BenchmarkLargeRouterLateMatch(b *testing.B) {
+	router := setupLargeRouteBench(b)
+	req := httptest.NewRequest("DELETE", "/resource299/42", nil)
+
+	for n := 0; n < b.N; n++ {
+		router.match(req, &routeMatch{currentPath: req.URL.Path})
+	}
+}
+
+func BenchmarkLargeRouterNotFound(b *testing.B) {
+	router := setupLargeRouteBench(b)
+	req := httptest.NewRequest("GET", "/resource299/42/notfound", nil)
+
+	for n := 0; n < b.N; n++ {
+		router.match(req, &routeMatch{currentPath: req.URL.Path})
+	}
+}
+
+func BenchmarkStaticLongPath(b *testing.B) {
+	router := setupRouteBench(b)
+	router.Static("/static", "resources", false)
+	req := httptest.NewRequest("GET", "/static/"+strings.Repeat("directory/", 400)+"file.txt", nil)
+
+	for n := 0; n < b.N; n++ {
+		router.match(req, &routeMatch{currentPath: req.URL.Path})
+	}
+}
+
+func BenchmarkRegexLongPath(b *testing.B) {
+	router := setupRouteBench(b)
+	router.Get("/files/{path:[a-z/]+}/{name:[a-z]+\\.txt}", handler)
+	req := httptest.NewRequest("GET", "/files/"+strings.Repeat("directory/", 400)+"file.txt", nil)
+
+	for n := 0; n < b.N; n++ {
+		router.match(req, &routeMatch{currentPath: req.URL.Path})
+	}
+}
+
+func setupLargeRouteBench(b *testing.B) *Router {
+	router := NewRouter()
+	for i := 0; i < 300; i++ {
+		uri := fmt.Sprintf("/resource%d", i)
+		router.Get(uri, handler)
+		router.Post(uri, handler)
+		router.Get(uri+"/{id:[0-9]+}", handler)
+		router.Route("PUT|PATCH", uri+"/{id:[0-9]+}", handler)
+		router.Delete(uri+"/{id:[0-9]+}", handler)
+	}
+	b.ReportAllocs()
+	runtime.GC()
+	defer b.ResetTimer()
+	return router
+}
+
 func setupRouteBench(b *testing.B) *Router {
 	router := registerAll(sampleRouteDefinition)
 	b.ReportAllocs()
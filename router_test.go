@@ -4,11 +4,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strconv"
 	"testing"
+	"testing/fstest"
 
 	"goyave.dev/goyave/v3/config"
 	"goyave.dev/goyave/v3/cors"
+	"goyave.dev/goyave/v3/validation"
 )
 
 type RouterTestSuite struct {
@@ -102,20 +105,23 @@ func (suite *RouterTestSuite) TestSubRouter() {
 }
 
 func (suite *RouterTestSuite) TestCleanStaticPath() {
-	suite.Equal("config/index.html", cleanStaticPath("config", "index.html"))
-	suite.Equal("config/index.html", cleanStaticPath("config", ""))
-	suite.Equal("config/defaults.json", cleanStaticPath("config", "defaults.json"))
-	suite.Equal("resources/lang/en-US/locale.json", cleanStaticPath("resources", "lang/en-US/locale.json"))
-	suite.Equal("resources/lang/en-US/locale.json", cleanStaticPath("resources", "/lang/en-US/locale.json"))
-	suite.Equal("resources/img/logo/index.html", cleanStaticPath("resources", "img/logo"))
-	suite.Equal("resources/img/logo/index.html", cleanStaticPath("resources", "img/logo/"))
-	suite.Equal("resources/img/index.html", cleanStaticPath("resources", "img"))
-	suite.Equal("resources/img/index.html", cleanStaticPath("resources", "img/"))
+	configFS := os.DirFS("config")
+	resourcesFS := os.DirFS("resources")
+	suite.Equal("index.html", cleanStaticPath(configFS, "index.html"))
+	suite.Equal("index.html", cleanStaticPath(configFS, ""))
+	suite.Equal("defaults.json", cleanStaticPath(configFS, "defaults.json"))
+	suite.Equal("lang/en-US/locale.json", cleanStaticPath(resourcesFS, "lang/en-US/locale.json"))
+	suite.Equal("lang/en-US/locale.json", cleanStaticPath(resourcesFS, "/lang/en-US/locale.json"))
+	suite.Equal("img/logo/index.html", cleanStaticPath(resourcesFS, "img/logo"))
+	suite.Equal("img/logo/index.html", cleanStaticPath(resourcesFS, "img/logo/"))
+	suite.Equal("img/index.html", cleanStaticPath(resourcesFS, "img"))
+	suite.Equal("img/index.html", cleanStaticPath(resourcesFS, "img/"))
+	suite.Equal("lang/en-US/locale.json", cleanStaticPath(resourcesFS, "../lang/../lang/en-US/locale.json"))
 }
 
 func (suite *RouterTestSuite) TestStaticHandler() {
 	request, response := createRouterTestRequest("/config.test.json")
-	handler := staticHandler("config", false)
+	handler := staticHandler(os.DirFS("config"), &StaticOptions{})
 	handler(response, request)
 	result := response.responseWriter.(*httptest.ResponseRecorder).Result()
 	suite.Equal(200, result.StatusCode)
@@ -131,7 +137,7 @@ func (suite *RouterTestSuite) TestStaticHandler() {
 	suite.True(len(body) > 0)
 
 	request, response = createRouterTestRequest("/doesn'texist")
-	handler = staticHandler("config", false)
+	handler = staticHandler(os.DirFS("config"), &StaticOptions{})
 	handler(response, request)
 	result = response.responseWriter.(*httptest.ResponseRecorder).Result()
 	suite.Equal(200, result.StatusCode) // Not written yet
@@ -146,7 +152,7 @@ func (suite *RouterTestSuite) TestStaticHandler() {
 	suite.Equal(0, len(body))
 
 	request, response = createRouterTestRequest("/config.test.json")
-	handler = staticHandler("config", true)
+	handler = staticHandler(os.DirFS("config"), &StaticOptions{Download: true})
 	handler(response, request)
 	result = response.responseWriter.(*httptest.ResponseRecorder).Result()
 	suite.Equal(200, result.StatusCode)
@@ -162,6 +168,69 @@ func (suite *RouterTestSuite) TestStaticHandler() {
 	suite.True(len(body) > 0)
 }
 
+func (suite *RouterTestSuite) TestStaticHandlerFS() {
+	fsys := fstest.MapFS{
+		"index.html":    {Data: []byte("<html></html>")},
+		"js/app.js":     {Data: []byte("console.log('hello')")},
+		"js/index.html": {Data: []byte("<html>js</html>")},
+	}
+
+	request, response := createRouterTestRequest("/")
+	staticHandler(fsys, &StaticOptions{})(response, request)
+	result := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err := ioutil.ReadAll(result.Body)
+	result.Body.Close()
+	suite.Nil(err)
+	suite.Equal(200, result.StatusCode)
+	suite.Equal("<html></html>", string(body))
+
+	request, response = createRouterTestRequest("/js")
+	staticHandler(fsys, &StaticOptions{})(response, request)
+	result = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err = ioutil.ReadAll(result.Body)
+	result.Body.Close()
+	suite.Nil(err)
+	suite.Equal("<html>js</html>", string(body))
+
+	request, response = createRouterTestRequest("/js/app.js")
+	staticHandler(fsys, &StaticOptions{Download: true})(response, request)
+	result = response.responseWriter.(*httptest.ResponseRecorder).Result()
+	result.Body.Close()
+	suite.Equal("attachment; filename=\"app.js\"", result.Header.Get("Content-Disposition"))
+
+	request, response = createRouterTestRequest("/js/doesn'texist")
+	staticHandler(fsys, &StaticOptions{})(response, request)
+	suite.Equal(404, response.GetStatus())
+
+	router := NewRouter()
+	router.StaticFS("/public", fsys, false)
+	suite.NotNil(router.routes[0].handler)
+	suite.Equal("/public{resource:.*}", router.routes[0].uri)
+}
+
+func (suite *RouterTestSuite) TestStaticHandlerConditional() {
+	request, _ := createRouterTestRequest("/config.test.json")
+	request.httpRequest.Header.Set("Range", "bytes=0-0")
+	response := newResponse(httptest.NewRecorder(), request.httpRequest)
+	staticHandler(os.DirFS("config"), &StaticOptions{})(response, request)
+	result := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		panic(err)
+	}
+	result.Body.Close()
+	suite.Equal(http.StatusPartialContent, result.StatusCode)
+	suite.Equal("{", string(body))
+	etag := result.Header.Get("ETag")
+	suite.NotEmpty(etag)
+
+	request, _ = createRouterTestRequest("/config.test.json")
+	request.httpRequest.Header.Set("If-None-Match", etag)
+	response = newResponse(httptest.NewRecorder(), request.httpRequest)
+	staticHandler(os.DirFS("config"), &StaticOptions{Download: true})(response, request)
+	suite.Equal(http.StatusNotModified, response.GetStatus())
+}
+
 func (suite *RouterTestSuite) TestRequestHandler() {
 	rawRequest := httptest.NewRequest("GET", "/uri", nil)
 	writer := httptest.NewRecorder()
@@ -291,6 +360,64 @@ func (suite *RouterTestSuite) TestErrorStatusHandler() {
 	suite.Equal("{\"error\":\""+http.StatusText(404)+"\"}\n", string(body))
 }
 
+func (suite *RouterTestSuite) TestValidationStatusHandler() {
+	prev := config.GetString("server.validationErrorFormat")
+	defer config.Set("server.validationErrorFormat", prev)
+
+	createValidationErrorRequest := func() (*Request, *Response) {
+		request, response := createRouterTestRequest("/uri")
+		request.Data = map[string]interface{}{"items": []interface{}{map[string]interface{}{"quantity": 0}}}
+		request.Rules = validation.RuleSet{"items[].quantity": {"integer", "min:1"}}.AsRules()
+		request.Lang = "en-US"
+		response.err = request.validate()
+		response.Status(http.StatusUnprocessableEntity)
+		return request, response
+	}
+
+	getBody := func(response *Response) (*http.Response, string) {
+		result := response.responseWriter.(*httptest.ResponseRecorder).Result()
+		body, err := ioutil.ReadAll(result.Body)
+		if err != nil {
+			panic(err)
+		}
+		result.Body.Close()
+		return result, string(body)
+	}
+
+	config.Set("server.validationErrorFormat", "default")
+	request, response := createValidationErrorRequest()
+	ValidationStatusHandler(response, request)
+	result, body := getBody(response)
+	suite.Equal(http.StatusUnprocessableEntity, result.StatusCode)
+	suite.Equal("application/json; charset=utf-8", result.Header.Get("Content-Type"))
+	suite.Equal("{\"validationError\":{\"items.0.quantity\":[\"The quantity must be at least 1.\"]}}\n", body)
+
+	config.Set("server.validationErrorFormat", "detailed")
+	request, response = createValidationErrorRequest()
+	ValidationStatusHandler(response, request)
+	result, body = getBody(response)
+	suite.Equal(http.StatusUnprocessableEntity, result.StatusCode)
+	suite.Equal("application/json; charset=utf-8", result.Header.Get("Content-Type"))
+	suite.Equal("{\"validationError\":{\"fields\":{\"items\":{\"elements\":{\"0\":{\"fields\":{\"quantity\":{\"errors\":[{\"rule\":\"min\",\"params\":[\"1\"],\"message\":\"The quantity must be at least 1.\"}]}}}}}}}}\n", body)
+
+	config.Set("server.validationErrorFormat", "problem")
+	request, response = createValidationErrorRequest()
+	ValidationStatusHandler(response, request)
+	result, body = getBody(response)
+	suite.Equal(http.StatusUnprocessableEntity, result.StatusCode)
+	suite.Equal("application/problem+json; charset=utf-8", result.Header.Get("Content-Type"))
+	suite.Equal("{\"type\":\"about:blank\",\"title\":\"Unprocessable Entity\",\"status\":422,\"instance\":\"/uri\",\"errors\":{\"fields\":{\"items\":{\"elements\":{\"0\":{\"fields\":{\"quantity\":{\"errors\":[{\"rule\":\"min\",\"params\":[\"1\"],\"message\":\"The quantity must be at least 1.\"}]}}}}}}}}\n", body)
+
+	// No details available, the flat errors are used
+	request, response = createRouterTestRequest("/uri")
+	response.err = validation.Errors{"error": {"Malformed JSON"}}
+	response.Status(http.StatusBadRequest)
+	ValidationStatusHandler(response, request)
+	result, body = getBody(response)
+	suite.Equal(http.StatusBadRequest, result.StatusCode)
+	suite.Equal("{\"type\":\"about:blank\",\"title\":\"Bad Request\",\"status\":400,\"instance\":\"/uri\",\"errors\":{\"error\":[\"Malformed JSON\"]}}\n", body)
+}
+
 func (suite *RouterTestSuite) TestStatusHandlers() {
 	rawRequest := httptest.NewRequest("GET", "/uri", nil)
 	writer := httptest.NewRecorder()
@@ -397,6 +524,149 @@ func (suite *RouterTestSuite) TestRouteNoMatch() {
 	result.Body.Close()
 }
 
+func (suite *RouterTestSuite) TestMethodNotAllowedAllowHeader() {
+	handler := func(response *Response, request *Request) {}
+	router := NewRouter()
+	router.Get("/product", handler)
+	group := router.Group()
+	group.Post("/product", handler)
+	group.Route("PUT|PATCH", "/product", handler)
+	router.Delete("/product/{id:[0-9]+}", handler)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("DELETE", "/product", nil))
+	result := recorder.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	suite.Nil(err)
+	result.Body.Close()
+	suite.Equal(http.StatusMethodNotAllowed, result.StatusCode)
+	suite.Equal("POST, PUT, PATCH, GET, HEAD, OPTIONS", result.Header.Get("Allow"))
+	suite.Equal("{\"error\":\"Method Not Allowed\"}\n", string(body))
+
+	// Overridden by status handler
+	router.StatusHandler(func(response *Response, request *Request) {
+		response.Header().Set("Allow", "GET")
+		response.String(http.StatusMethodNotAllowed, "custom")
+	}, http.StatusMethodNotAllowed)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/product/1", nil))
+	result = recorder.Result()
+	body, err = ioutil.ReadAll(result.Body)
+	suite.Nil(err)
+	result.Body.Close()
+	suite.Equal(http.StatusMethodNotAllowed, result.StatusCode)
+	suite.Equal("GET", result.Header.Get("Allow"))
+	suite.Equal("custom", string(body))
+}
+
+func (suite *RouterTestSuite) TestGroupsSharingPath() {
+	handler := func(response *Response, request *Request) {
+		response.String(http.StatusOK, request.Method())
+	}
+	router := NewRouter()
+	router.Group().Get("/product", handler)
+	router.Group().Post("/product", handler)
+	router.Group().Delete("/product", handler)
+
+	req := httptest.NewRequest("PUT", "/product", nil)
+	match := routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(methodNotAllowedRoute, match.route)
+	suite.Equal([]string{"GET", "HEAD", "POST", "DELETE", "OPTIONS"}, match.getAllowedMethods())
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("PUT", "/product", nil))
+	result := recorder.Result()
+	result.Body.Close()
+	suite.Equal(http.StatusMethodNotAllowed, result.StatusCode)
+	suite.Equal("GET, HEAD, POST, DELETE, OPTIONS", result.Header.Get("Allow"))
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/product", nil))
+	result = recorder.Result()
+	result.Body.Close()
+	suite.Equal(http.StatusNoContent, result.StatusCode)
+	suite.Equal("GET, HEAD, POST, DELETE, OPTIONS", result.Header.Get("Allow"))
+
+	// The method is found in a later group
+	for _, method := range []string{"POST", "DELETE"} {
+		recorder = httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest(method, "/product", nil))
+		result = recorder.Result()
+		body, err := ioutil.ReadAll(result.Body)
+		suite.Nil(err)
+		result.Body.Close()
+		suite.Equal(http.StatusOK, result.StatusCode)
+		suite.Equal(method, string(body))
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/other", nil))
+	result = recorder.Result()
+	result.Body.Close()
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+}
+
+func (suite *RouterTestSuite) TestAutomaticOptions() {
+	handler := func(response *Response, request *Request) {
+		response.String(http.StatusOK, "hello")
+	}
+	router := NewRouter()
+	router.Get("/product", handler)
+	router.Post("/product", handler)
+	router.Options("/custom", handler)
+	router.Get("/custom", handler)
+
+	req := httptest.NewRequest("OPTIONS", "/product", nil)
+	match := routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(optionsRoute, match.route)
+	suite.Equal([]string{"GET", "HEAD", "POST", "OPTIONS"}, match.getAllowedMethods())
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	result := recorder.Result()
+	body, err := ioutil.ReadAll(result.Body)
+	suite.Nil(err)
+	result.Body.Close()
+	suite.Equal(http.StatusNoContent, result.StatusCode)
+	suite.Equal("GET, HEAD, POST, OPTIONS", result.Header.Get("Allow"))
+	suite.Empty(body)
+
+	// Route explicitly handling OPTIONS
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/custom", nil))
+	result = recorder.Result()
+	body, err = ioutil.ReadAll(result.Body)
+	suite.Nil(err)
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Empty(result.Header.Get("Allow"))
+	suite.Equal("hello", string(body))
+
+	// Not found
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/notfound", nil))
+	result = recorder.Result()
+	result.Body.Close()
+	suite.Equal(http.StatusNotFound, result.StatusCode)
+	suite.Empty(result.Header.Get("Allow"))
+
+	// Overridden by status handler
+	router.StatusHandler(func(response *Response, request *Request) {
+		response.String(http.StatusOK, "options")
+	}, http.StatusNoContent)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/product", nil))
+	result = recorder.Result()
+	body, err = ioutil.ReadAll(result.Body)
+	suite.Nil(err)
+	result.Body.Close()
+	suite.Equal(http.StatusOK, result.StatusCode)
+	suite.Equal("GET, HEAD, POST, OPTIONS", result.Header.Get("Allow"))
+	suite.Equal("options", string(body))
+}
+
 func (suite *RouterTestSuite) TestNamedRoutes() {
 	r := NewRouter()
 	route := r.Route("GET", "/uri", func(resp *Response, r *Request) {})
@@ -417,6 +687,131 @@ func (suite *RouterTestSuite) TestNamedRoutes() {
 	router = r
 	suite.Equal(route, GetRoute("get-uri"))
 	router = nil
+	suite.Nil(GetRoute("get-uri"))
+}
+
+func (suite *RouterTestSuite) TestWithoutMiddleware() {
+	headerMiddleware := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(response *Response, r *Request) {
+				response.Header().Add("X-Middleware", name)
+				next(response, r)
+			}
+		}
+	}
+	authMiddleware := func(next Handler) Handler {
+		return func(response *Response, r *Request) {
+			response.Header().Add("X-Middleware", "auth")
+			next(response, r)
+		}
+	}
+	handler := func(response *Response, r *Request) {}
+	serve := func(router *Router, uri string) []string {
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, httptest.NewRequest("GET", uri, nil))
+		resp := recorder.Result()
+		resp.Body.Close()
+		return resp.Header.Values("X-Middleware")
+	}
+
+	root := headerMiddleware("root")
+	router := NewRouter()
+	router.Middleware(authMiddleware, root)
+	router.Get("/profile", handler)
+	router.Get("/login", handler).WithoutMiddleware(authMiddleware)
+	router.Get("/raw", handler).WithoutMiddleware(authMiddleware, root)
+
+	suite.Equal([]string{"auth", "root"}, serve(router, "/profile"))
+	suite.Equal([]string{"root"}, serve(router, "/login"))
+	suite.Empty(serve(router, "/raw"))
+
+	// Closures created by the same function are different middleware
+	router.Get("/other", handler).WithoutMiddleware(headerMiddleware("root"))
+	suite.Equal([]string{"auth", "root"}, serve(router, "/other"))
+
+	// Route middleware are not excluded
+	router.Get("/own", handler).WithoutMiddleware(authMiddleware).Middleware(authMiddleware)
+	suite.Equal([]string{"root", "auth"}, serve(router, "/own"))
+
+	// Router-level exclusion
+	public := router.Subrouter("/public")
+	public.WithoutMiddleware(authMiddleware)
+	publicMiddleware := headerMiddleware("public")
+	public.Middleware(publicMiddleware)
+	public.Get("/", handler)
+	sub := public.Subrouter("/sub")
+	sub.Middleware(authMiddleware)
+	sub.Get("/", handler)
+	other := sub.Subrouter("/other")
+	other.Get("/", handler)
+
+	suite.Equal([]string{"root", "public"}, serve(router, "/public"))
+	// The middleware of the router excluding it are not affected
+	suite.Equal([]string{"root", "public", "auth"}, serve(router, "/public/sub"))
+	suite.Equal([]string{"root", "public", "auth"}, serve(router, "/public/sub/other"))
+
+	other.WithoutMiddleware(root, publicMiddleware)
+	suite.Equal([]string{"auth"}, serve(router, "/public/sub/other"))
+
+	// Method values are different middleware every time they are evaluated
+	methods := NewRouter()
+	mw := &methodMiddleware{name: "method"}
+	methods.Middleware(mw.Handle)
+	methods.Get("/", handler).WithoutMiddleware(mw.Handle)
+	suite.Equal([]string{"method"}, serve(methods, "/"))
+
+	route := router.Get("/shared", handler).WithoutMiddleware(authMiddleware)
+	suite.Same(route, route.WithoutMiddleware())
+	suite.Len(route.excluded, 1)
+	// The middleware itself is kept so it cannot be collected
+	suite.Equal(middlewareIdentity(authMiddleware), middlewareIdentity(route.excluded[0]))
+}
+
+type methodMiddleware struct {
+	name string
+}
+
+func (m *methodMiddleware) Handle(next Handler) Handler {
+	return func(response *Response, r *Request) {
+		response.Header().Add("X-Middleware", m.name)
+		next(response, r)
+	}
+}
+
+func (suite *RouterTestSuite) TestNamePrefix() {
+	r := NewRouter()
+	handler := func(resp *Response, r *Request) {}
+	admin := r.Subrouter("/admin")
+	admin.NamePrefix("admin.")
+	users := admin.Subrouter("/users")
+	users.NamePrefix("users.")
+
+	index := users.Get("/", handler).Name("index")
+	suite.Equal("admin.users.index", index.GetName())
+	suite.Same(index, r.GetRoute("admin.users.index"))
+	suite.Same(index, users.GetRoute("admin.users.index"))
+	suite.Nil(r.GetRoute("index"))
+
+	dashboard := admin.Get("/", handler).Name("dashboard")
+	suite.Equal("admin.dashboard", dashboard.GetName())
+
+	// Groups without prefix inherit the name prefix
+	group := users.Group()
+	show := group.Get("/{id}", handler).Name("show")
+	suite.Equal("admin.users.show", show.GetName())
+
+	// Collisions across nested routers
+	suite.Panics(func() {
+		r.Get("/index", handler).Name("admin.users.index")
+	})
+	suite.Panics(func() {
+		group.Get("/other", handler).Name("index")
+	})
+
+	// Resources
+	resource := users.Resource("/articles", fullResourceController{}, nil)
+	suite.Equal("admin.users.articles.index", resource.GetRoute("index").GetName())
+	suite.Same(resource.GetRoute("show"), r.GetRoute("admin.users.articles.show"))
 }
 
 func (suite *RouterTestSuite) TestMiddleware() {
@@ -683,6 +1078,48 @@ func (suite *RouterTestSuite) TestConflictingRoutes() {
 	router.match(req, &match)
 
 	suite.Equal(notFoundRoute, match.route)
+
+	// Test sibling subrouters sharing a prefix
+	router = NewRouter()
+	apiRouter := router.Subrouter("/api")
+	apiRouter.Route("GET", "/users", handler)
+	v2Router := router.Subrouter("/api/v2")
+	routeV2 := v2Router.Route("GET", "/users", handler)
+
+	req = httptest.NewRequest("GET", "/api/v2/users", nil)
+	match = routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(routeV2, match.route)
+	suite.Equal("/users", match.currentPath)
+}
+
+func (suite *RouterTestSuite) TestMatchRegistrationOrder() {
+	handler := func(response *Response, request *Request) {}
+	router := NewRouter()
+	param := router.Route("GET", "/{name}", handler)
+	static := router.Route("GET", "/hello", handler)
+	post := router.Route("POST", "/hello", handler)
+
+	req := httptest.NewRequest("GET", "/hello", nil)
+	match := routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(param, match.route)
+	suite.Equal("hello", match.parameters["name"])
+
+	req = httptest.NewRequest("POST", "/hello", nil)
+	match = routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(post, match.route)
+
+	router = NewRouter()
+	static = router.Route("GET", "/hello", handler)
+	router.Route("GET", "/{name}", handler)
+
+	req = httptest.NewRequest("GET", "/hello", nil)
+	match = routeMatch{currentPath: req.URL.Path}
+	suite.True(router.match(req, &match))
+	suite.Equal(static, match.route)
+	suite.Nil(match.parameters)
 }
 
 func (suite *RouterTestSuite) TestSubrouterEmptyPrefix() {
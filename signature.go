@@ -0,0 +1,143 @@
+package goyave
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"goyave.dev/goyave/v3/config"
+)
+
+const (
+	signatureParameter = "signature"
+	expiresParameter   = "expires"
+)
+
+// BuildSignedURL build a full URL pointing to this route, signed with the
+// "app.key" config entry. Signed URLs cannot be modified without invalidating
+// their signature, which makes them suitable for temporary download links,
+// email verification or unsubscribe links. Use the "middleware.ValidateSignature"
+// middleware or "Request.HasValidSignature()" to check the signature.
+//
+// The URL expires after the given duration. If the duration is 0, the URL never expires.
+//  route.BuildSignedURL(24*time.Hour, "42")
+//  // "http://127.0.0.1:8080/files/42?expires=1620000000&signature=..."
+//
+// The signature covers the path and the query, not the protocol and port.
+// If the route has a host pattern (see "Route.GetHost"), the signature covers
+// the host too, so the URL is not valid on another host matching the pattern.
+// Otherwise, the URL built by "BuildSignedURI" has the same signature.
+//
+// The "expires" and "signature" query parameters are reserved. Panics if the
+// built URL already has one of them, if the "app.key" config entry is empty or if
+// the amount of parameters doesn't match the amount of actual parameters for this route.
+func (r *Route) BuildSignedURL(expiry time.Duration, parameters ...string) string {
+	return signURL(r.BuildURL(parameters...), r.signedHost(parameters), expiry)
+}
+
+// BuildSignedURI build a URI pointing to this route, signed with the "app.key"
+// config entry. The returned string doesn't include the protocol and domain.
+// If the route has a host pattern, the values of the host parameters come
+// first, like with "BuildURL", and the URI is only valid on this host.
+// The "expires" and "signature" query parameters are reserved.
+// See "BuildSignedURL" for more details.
+func (r *Route) BuildSignedURI(expiry time.Duration, parameters ...string) string {
+	host := r.signedHost(parameters)
+	if pattern := r.getHostPattern(); pattern != nil {
+		parameters = parameters[len(pattern.parameters):]
+	}
+	return signURL(r.BuildURI(parameters...), host, expiry)
+}
+
+// signedHost returns the lowercase host covered by the signature of the URLs
+// of this route, built from the leading host parameters. Returns an empty
+// string if the route doesn't have a host pattern.
+func (r *Route) signedHost(parameters []string) string {
+	pattern := r.getHostPattern()
+	if pattern == nil {
+		return ""
+	}
+	count := len(pattern.parameters)
+	if len(parameters) < count {
+		panic(fmt.Errorf("Cannot sign URL: route has %d host parameters, %d given", count, len(parameters)))
+	}
+	return strings.ToLower(pattern.build(parameters[:count]))
+}
+
+// signURL adds the "expires" (if expiry is not 0) and "signature"
+// query parameters to the given URL. The signature covers the given
+// host, which can be empty. Panics if the URL already has one of
+// these parameters.
+func signURL(rawURL string, host string, expiry time.Duration) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		panic(err)
+	}
+	query := u.Query()
+	for _, name := range []string{signatureParameter, expiresParameter} {
+		if _, ok := query[name]; ok {
+			panic(fmt.Errorf("Cannot sign URL: the %q query parameter is reserved", name))
+		}
+	}
+	if expiry != 0 {
+		query.Set(expiresParameter, strconv.FormatInt(time.Now().Add(expiry).Unix(), 10))
+	}
+	query.Set(signatureParameter, sign(host, u.EscapedPath(), query))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// sign returns the hexadecimal HMAC-SHA256 signature of the given
+// host, path and query, ignoring the "signature" query parameter.
+func sign(host string, path string, query url.Values) string {
+	key := config.GetString("app.key")
+	if key == "" {
+		panic("Cannot sign URL: the \"app.key\" config entry is empty")
+	}
+	signature := query[signatureParameter]
+	query.Del(signatureParameter)
+	payload := host + path + "?" + query.Encode()
+	if signature != nil {
+		query[signatureParameter] = signature
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HasValidSignature returns true if the requested URL has been signed with
+// the "app.key" config entry and is not expired. If the matched route has a
+// host pattern, the URL must have been signed for the requested host.
+// See "Route.BuildSignedURL" for more details.
+//
+// Panics if the "app.key" config entry is empty.
+func (r *Request) HasValidSignature() bool {
+	query := r.URI().Query()
+	signature := query.Get(signatureParameter)
+	if signature == "" {
+		return false
+	}
+
+	host := ""
+	if r.route != nil && r.route.getHostPattern() != nil {
+		host = requestHost(r.httpRequest)
+	}
+	expected := sign(host, r.URI().EscapedPath(), query)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return false
+	}
+
+	if expires := query.Get(expiresParameter); expires != "" {
+		timestamp, err := strconv.ParseInt(expires, 10, 64)
+		if err != nil || time.Now().Unix() > timestamp {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,181 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"goyave.dev/goyave/v3/config"
+)
+
+type SignatureTestSuite struct {
+	TestSuite
+}
+
+func (suite *SignatureTestSuite) SetupTest() {
+	config.Set("app.key", "secret")
+}
+
+func (suite *SignatureTestSuite) TearDownTest() {
+	config.Set("app.key", "")
+}
+
+func (suite *SignatureTestSuite) createRequest(uri string) *Request {
+	return suite.CreateTestRequest(httptest.NewRequest("GET", uri, nil))
+}
+
+func (suite *SignatureTestSuite) TestBuildSignedURL() {
+	router := NewRouter()
+	route := router.Get("/files/{file}", func(response *Response, r *Request) {})
+
+	signed := route.BuildSignedURL(time.Hour, "report.pdf")
+	suite.True(strings.HasPrefix(signed, BaseURL()+"/files/report.pdf?"))
+	u, err := url.Parse(signed)
+	suite.Nil(err)
+	query := u.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	suite.Nil(err)
+	suite.InDelta(time.Now().Add(time.Hour).Unix(), expires, 2)
+	suite.Len(query.Get("signature"), 64)
+
+	// Relative and absolute URLs have the same signature
+	uri := route.BuildSignedURI(time.Hour, "report.pdf")
+	suite.True(strings.HasPrefix(uri, "/files/report.pdf?"))
+	suite.Equal(signed, BaseURL()+uri)
+
+	// No expiry
+	uri = route.BuildSignedURI(0, "report.pdf")
+	suite.NotContains(uri, "expires")
+	suite.Contains(uri, "signature=")
+
+	suite.Panics(func() {
+		route.BuildSignedURL(time.Hour)
+	})
+	suite.Panics(func() { // Reserved query parameter
+		route.BuildSignedURI(time.Hour, "report.pdf?signature=forged")
+	})
+
+	config.Set("app.key", "")
+	suite.Panics(func() {
+		route.BuildSignedURL(time.Hour, "report.pdf")
+	})
+}
+
+func (suite *SignatureTestSuite) TestSignURL() {
+	// Existing query parameters are signed too
+	signed := signURL("/files?name=report", "", 0)
+	u, err := url.Parse(signed)
+	suite.Nil(err)
+	suite.Equal("report", u.Query().Get("name"))
+	suite.Empty(u.Query().Get("expires"))
+	suite.True(suite.createRequest(signed).HasValidSignature())
+
+	// Reserved query parameters
+	suite.Panics(func() {
+		signURL("/files?name=report&signature=forged", "", 0)
+	})
+	suite.Panics(func() {
+		signURL("/files?expires=1", "", time.Minute)
+	})
+	suite.Panics(func() {
+		signURL("/files?expires=", "", 0)
+	})
+
+	tampered := strings.Replace(signed, "name=report", "name=other", 1)
+	suite.False(suite.createRequest(tampered).HasValidSignature())
+
+	// Path parameters are escaped
+	signed = signURL("/files/my report.pdf", "", time.Minute)
+	suite.True(strings.HasPrefix(signed, "/files/my%20report.pdf?"))
+	suite.True(suite.createRequest(signed).HasValidSignature())
+}
+
+func (suite *SignatureTestSuite) TestHasValidSignature() {
+	router := NewRouter()
+	route := router.Get("/files/{file}", func(response *Response, r *Request) {})
+
+	suite.True(suite.createRequest(route.BuildSignedURL(time.Hour, "report.pdf")).HasValidSignature())
+	suite.True(suite.createRequest(route.BuildSignedURI(time.Hour, "report.pdf")).HasValidSignature())
+	suite.True(suite.createRequest(route.BuildSignedURI(0, "report.pdf")).HasValidSignature())
+
+	// Expired
+	suite.False(suite.createRequest(route.BuildSignedURI(-time.Second, "report.pdf")).HasValidSignature())
+
+	// Not signed
+	suite.False(suite.createRequest("/files/report.pdf").HasValidSignature())
+
+	// Tampered path
+	uri := route.BuildSignedURI(time.Hour, "report.pdf")
+	suite.False(suite.createRequest(strings.Replace(uri, "report.pdf", "secret.pdf", 1)).HasValidSignature())
+
+	// Tampered expiry
+	u, _ := url.Parse(uri)
+	query := u.Query()
+	query.Set("expires", strconv.FormatInt(time.Now().Add(48*time.Hour).Unix(), 10))
+	u.RawQuery = query.Encode()
+	suite.False(suite.createRequest(u.String()).HasValidSignature())
+
+	// Removed expiry
+	query.Del("expires")
+	u.RawQuery = query.Encode()
+	suite.False(suite.createRequest(u.String()).HasValidSignature())
+
+	// Different key
+	config.Set("app.key", "other")
+	suite.False(suite.createRequest(uri).HasValidSignature())
+
+	config.Set("app.key", "")
+	suite.Panics(func() {
+		suite.createRequest(uri).HasValidSignature()
+	})
+}
+
+func (suite *SignatureTestSuite) TestSignedHost() {
+	router := NewRouter()
+	tenant := router.Group()
+	tenant.Host("{tenant}.example.com")
+	route := tenant.Get("/files/{file}", func(response *Response, r *Request) {
+		response.String(http.StatusOK, strconv.FormatBool(r.HasValidSignature()))
+	})
+	serve := func(host string, uri string) string {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", uri, nil)
+		req.Host = host
+		router.ServeHTTP(recorder, req)
+		resp := recorder.Result()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			panic(err)
+		}
+		resp.Body.Close()
+		return string(body)
+	}
+
+	signed := route.BuildSignedURL(time.Hour, "acme", "42")
+	u, err := url.Parse(signed)
+	suite.Nil(err)
+	suite.Equal("acme.example.com", u.Hostname())
+	suite.Equal("true", serve(u.Host, u.RequestURI()))
+	suite.Equal("true", serve("ACME.example.com", u.RequestURI()))
+
+	// Replayed on another host matching the pattern
+	suite.Equal("false", serve("other.example.com", u.RequestURI()))
+
+	uri := route.BuildSignedURI(time.Hour, "acme", "42")
+	suite.True(strings.HasPrefix(uri, "/files/42?"))
+	suite.Equal("true", serve("acme.example.com", uri))
+	suite.Equal("false", serve("other.example.com", uri))
+
+	suite.Panics(func() {
+		route.BuildSignedURI(time.Hour)
+	})
+}
+
+func TestSignatureSuite(t *testing.T) {
+	RunTest(t, new(SignatureTestSuite))
+}
@@ -0,0 +1,142 @@
+package goyave
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidEvent returned by "EventStream.Send()" if the event ID or name
+	// contains a line break.
+	ErrInvalidEvent = errors.New("Event ID and name cannot contain line breaks")
+)
+
+// Event a Server-Sent Event.
+// Empty fields are not written to the stream.
+//
+// See: https://html.spec.whatwg.org/multipage/server-sent-events.html
+type Event struct {
+
+	// ID the event ID, used by the client to resume the stream
+	// with the "Last-Event-ID" header after a reconnection.
+	ID string
+
+	// Event the event name. Clients listen to unnamed events
+	// using the "message" event.
+	Event string
+
+	// Data the event payload. Multi-line data is split
+	// into multiple "data" fields.
+	Data string
+
+	// Retry the reconnection time the client should use
+	// if the connection is lost.
+	Retry time.Duration
+}
+
+// EventStream writes Server-Sent Events to the client.
+// Each event is flushed through the chained writers as soon as it is sent.
+type EventStream struct {
+	response *Response
+	ctx      context.Context
+}
+
+// EventStream starts a Server-Sent Events stream. The "Content-Type" header is set
+// to "text/event-stream", caching and proxy buffering are disabled, and the response
+// header is written and flushed immediately with status "200 OK".
+//
+// The stream stops when the client disconnects: "Done()" is closed and
+// "Send()" returns the context error. The handler should return at that point.
+//
+//  stream := response.EventStream()
+//  for {
+//    select {
+//    case <-stream.Done():
+//      return
+//    case value := <-updates:
+//      if err := stream.Send(&goyave.Event{Event: "update", Data: value}); err != nil {
+//        return
+//      }
+//    }
+//  }
+func (r *Response) EventStream() *EventStream {
+	header := r.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	header.Del("Content-Length")
+
+	ctx := context.Background()
+	if r.httpRequest != nil {
+		ctx = r.httpRequest.Context()
+	}
+
+	r.Status(http.StatusOK)
+	r.Flush()
+	return &EventStream{
+		response: r,
+		ctx:      ctx,
+	}
+}
+
+// Context returns the context of the stream, which is
+// the context of the request.
+func (s *EventStream) Context() context.Context {
+	return s.ctx
+}
+
+// Done returns a channel that is closed when the client disconnects.
+func (s *EventStream) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Send writes the given event to the stream and flushes it.
+// Returns the context error if the client disconnected.
+func (s *EventStream) Send(event *Event) error {
+	if strings.ContainsAny(event.ID, "\r\n") || strings.ContainsAny(event.Event, "\r\n") {
+		return ErrInvalidEvent
+	}
+
+	var b bytes.Buffer
+	if event.ID != "" {
+		b.WriteString("id: " + event.ID + "\n")
+	}
+	if event.Event != "" {
+		b.WriteString("event: " + event.Event + "\n")
+	}
+	if event.Retry > 0 {
+		b.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+	if event.Data != "" {
+		data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(event.Data)
+		for _, line := range strings.Split(data, "\n") {
+			b.WriteString("data: " + line + "\n")
+		}
+	}
+	b.WriteByte('\n')
+	return s.write(b.Bytes())
+}
+
+// Comment writes a comment line to the stream and flushes it. Comments
+// are ignored by clients and can be used to keep the connection alive.
+func (s *EventStream) Comment(comment string) error {
+	comment = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(comment)
+	return s.write([]byte(": " + comment + "\n\n"))
+}
+
+func (s *EventStream) write(b []byte) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if _, err := s.response.Write(b); err != nil {
+		return err
+	}
+	s.response.Flush()
+	return nil
+}
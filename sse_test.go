@@ -0,0 +1,162 @@
+package goyave
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type SSETestSuite struct {
+	TestSuite
+}
+
+type flushCountingWriter struct {
+	io.Writer
+	flushed int
+}
+
+func (w *flushCountingWriter) Flush() {
+	w.flushed++
+	if flusher, ok := w.Writer.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type nonFlushableWriter struct {
+	io.Writer
+}
+
+func (suite *SSETestSuite) TestResponseFlush() {
+	recorder := httptest.NewRecorder()
+	response := newResponse(recorder, nil)
+	response.Status(http.StatusAccepted)
+	response.Flush()
+	suite.True(recorder.Flushed)
+	suite.True(response.IsHeaderWritten())
+	suite.Equal(http.StatusAccepted, recorder.Code)
+
+	// Chained writer implementing http.Flusher
+	recorder = httptest.NewRecorder()
+	response = newResponse(recorder, nil)
+	writer := &flushCountingWriter{Writer: recorder}
+	response.SetWriter(writer)
+	response.Flush()
+	suite.Equal(1, writer.flushed)
+	suite.True(recorder.Flushed)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Chained writer not implementing http.Flusher
+	recorder = httptest.NewRecorder()
+	response = newResponse(recorder, nil)
+	response.SetWriter(&nonFlushableWriter{Writer: recorder})
+	response.Flush()
+	suite.True(recorder.Flushed)
+}
+
+func (suite *SSETestSuite) TestEventStream() {
+	recorder := httptest.NewRecorder()
+	response := newResponse(recorder, httptest.NewRequest("GET", "/events", nil))
+	response.Header().Set("Content-Length", "10")
+	stream := response.EventStream()
+
+	suite.True(recorder.Flushed)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("text/event-stream", recorder.Header().Get("Content-Type"))
+	suite.Equal("no-cache", recorder.Header().Get("Cache-Control"))
+	suite.Equal("no", recorder.Header().Get("X-Accel-Buffering"))
+	suite.Empty(recorder.Header().Get("Content-Length"))
+	suite.Equal(response.httpRequest.Context(), stream.Context())
+
+	recorder.Flushed = false
+	suite.Nil(stream.Send(&Event{Data: "hello"}))
+	suite.True(recorder.Flushed)
+	suite.Equal("data: hello\n\n", recorder.Body.String())
+
+	recorder.Body.Reset()
+	suite.Nil(stream.Send(&Event{ID: "1", Event: "update", Data: "line 1\r\nline 2\rline 3\n", Retry: 2 * time.Second}))
+	suite.Equal("id: 1\nevent: update\nretry: 2000\ndata: line 1\ndata: line 2\ndata: line 3\ndata: \n\n", recorder.Body.String())
+
+	recorder.Body.Reset()
+	suite.Nil(stream.Send(&Event{Event: "ping"}))
+	suite.Equal("event: ping\n\n", recorder.Body.String())
+
+	recorder.Body.Reset()
+	suite.Nil(stream.Comment("keep\nalive"))
+	suite.Equal(": keep alive\n\n", recorder.Body.String())
+
+	recorder.Body.Reset()
+	suite.Equal(ErrInvalidEvent, stream.Send(&Event{ID: "1\n2"}))
+	suite.Equal(ErrInvalidEvent, stream.Send(&Event{Event: "a\rb"}))
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *SSETestSuite) TestEventStreamDisconnect() {
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := httptest.NewRecorder()
+	rawRequest := httptest.NewRequest("GET", "/events", nil).WithContext(ctx)
+	response := newResponse(recorder, rawRequest)
+	stream := response.EventStream()
+
+	select {
+	case <-stream.Done():
+		suite.Fail("Stream should not be done")
+	default:
+	}
+
+	cancel()
+	<-stream.Done()
+	suite.Equal(context.Canceled, stream.Send(&Event{Data: "hello"}))
+	suite.Equal(context.Canceled, stream.Comment("hello"))
+	suite.Empty(recorder.Body.String())
+}
+
+func (suite *SSETestSuite) TestEventStreamServer() {
+	suite.RunServer(func(router *Router) {
+		router.Route("GET", "/events", func(response *Response, request *Request) {
+			stream := response.EventStream()
+			for i := 0; i < 3; i++ {
+				if err := stream.Send(&Event{ID: string(rune('1' + i)), Data: "event"}); err != nil {
+					return
+				}
+			}
+			<-stream.Done()
+		})
+	}, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, "GET", BaseURL()+"/events", nil)
+		if err != nil {
+			panic(err)
+		}
+		resp, err := suite.getHTTPClient().Do(req)
+		if err != nil {
+			suite.Fail(err.Error())
+			return
+		}
+		defer resp.Body.Close()
+		suite.Equal(http.StatusOK, resp.StatusCode)
+		suite.Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+		// Events are received before the handler returns
+		reader := bufio.NewReader(resp.Body)
+		lines := make([]string, 0, 9)
+		for len(lines) < 9 {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				suite.Fail(err.Error())
+				return
+			}
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+		suite.Equal([]string{"id: 1", "data: event", "", "id: 2", "data: event", "", "id: 3", "data: event", ""}, lines)
+	})
+}
+
+func TestSSETestSuite(t *testing.T) {
+	RunTest(t, new(SSETestSuite))
+}
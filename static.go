@@ -0,0 +1,242 @@
+package goyave
+
+import (
+	htmltemplate "html/template"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"goyave.dev/goyave/v3/helper"
+	"goyave.dev/goyave/v3/helper/filesystem"
+)
+
+// DefaultFingerprintPattern matches file names containing a hexadecimal hash
+// of at least 8 characters before their extension, such as "app.3f2a9c1d.js"
+// or "style-8d5e957f.css".
+var DefaultFingerprintPattern = regexp.MustCompile(`[.-][0-9a-fA-F]{8,}\.[0-9a-zA-Z]+$`)
+
+// DefaultFingerprintCacheControl the "Cache-Control" header value used for
+// fingerprinted files if "StaticOptions.FingerprintCacheControl" is empty.
+// Their content never changes, so they can be cached for a year.
+const DefaultFingerprintCacheControl = "public, max-age=31536000, immutable"
+
+// StaticOptions options for static file serving.
+type StaticOptions struct {
+
+	// Fingerprint files whose path match this pattern are considered
+	// fingerprinted: their name changes when their content changes.
+	// Their "Cache-Control" header is set to "FingerprintCacheControl".
+	// Use "DefaultFingerprintPattern" or nil to disable.
+	Fingerprint *regexp.Regexp
+
+	// FingerprintCacheControl the "Cache-Control" header value for fingerprinted
+	// files. Defaults to "DefaultFingerprintCacheControl".
+	FingerprintCacheControl string
+
+	// CacheControl the "Cache-Control" header value for files that are
+	// not fingerprinted. The header is not set if empty.
+	CacheControl string
+
+	// Fallback the path of the file served when the requested file doesn't
+	// exist, for example "index.html" for single-page applications using
+	// client-side routing. If empty, "404 Not Found" is returned.
+	Fallback string
+
+	// Download set to true if you want the files to be sent as an attachment
+	// instead of an inline element.
+	Download bool
+
+	// Precompressed if true, the ".br" and ".gz" files next to the requested
+	// file are served instead of it if the client accepts the corresponding
+	// encoding. For example, "app.js.br" is served for "app.js" to clients
+	// accepting brotli.
+	Precompressed bool
+
+	// DirectoryListing if true, a list of the files is returned for
+	// directories not containing an "index.html" file. Hidden files
+	// (starting with a dot) are not listed.
+	DirectoryListing bool
+}
+
+var precompressedEncodings = []struct {
+	encoding  string
+	extension string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+var directoryListingTemplate = htmltemplate.Must(htmltemplate.New("directory").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Index of {{.Path}}</title></head>
+<body>
+<h1>Index of {{.Path}}</h1>
+<ul>
+{{- if .Parent}}
+<li><a href="{{.Parent}}">../</a></li>
+{{- end}}
+{{- range .Entries}}
+<li><a href="{{.URL}}">{{.Name}}</a></li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+type directoryEntry struct {
+	Name string
+	URL  string
+}
+
+func staticHandler(fsys fs.FS, options *StaticOptions) Handler {
+	return func(response *Response, r *Request) {
+		file := r.Params["resource"]
+		path := cleanStaticPath(fsys, file)
+		name := file[strings.LastIndex(file, "/")+1:]
+
+		if !filesystem.FileExistsFS(fsys, path) {
+			if options.DirectoryListing {
+				if dir := cleanFSPath(file); filesystem.IsDirectoryFS(fsys, dir) {
+					if err := listDirectory(response, r, fsys, dir); err != nil {
+						ErrLogger.Println(err)
+					}
+					return
+				}
+			}
+			if options.Fallback != "" && filesystem.FileExistsFS(fsys, options.Fallback) {
+				path = options.Fallback
+				name = options.Fallback[strings.LastIndex(options.Fallback, "/")+1:]
+			}
+		}
+
+		if err := serveStaticFile(response, r, fsys, path, name, options); err != nil {
+			if _, ok := err.(*os.PathError); !ok {
+				ErrLogger.Println(err)
+			}
+		}
+	}
+}
+
+func serveStaticFile(response *Response, r *Request, fsys fs.FS, file string, name string, options *StaticOptions) error {
+	if filesystem.FileExistsFS(fsys, file) {
+		header := response.Header()
+		if options.Fingerprint != nil && options.Fingerprint.MatchString(file) {
+			cacheControl := options.FingerprintCacheControl
+			if cacheControl == "" {
+				cacheControl = DefaultFingerprintCacheControl
+			}
+			header.Set("Cache-Control", cacheControl)
+		} else if options.CacheControl != "" {
+			header.Set("Cache-Control", options.CacheControl)
+		}
+
+		if options.Precompressed {
+			header.Add("Vary", "Accept-Encoding")
+			if encoding, sidecar := findPrecompressed(fsys, file, r.Header().Get("Accept-Encoding")); sidecar != "" {
+				if header.Get("Content-Type") == "" {
+					mime, _ := filesystem.GetMIMETypeFS(fsys, file)
+					header.Set("Content-Type", mime)
+				}
+				header.Set("Content-Encoding", encoding)
+				file = sidecar
+			}
+		}
+	}
+
+	if options.Download {
+		return response.DownloadFS(fsys, file, name)
+	}
+	return response.FileFS(fsys, file)
+}
+
+// findPrecompressed returns the encoding and the path of the precompressed
+// variant of the given file having the highest quality value in the given
+// "Accept-Encoding" header value. Returns empty strings if there is none.
+func findPrecompressed(fsys fs.FS, file string, acceptEncoding string) (string, string) {
+	if acceptEncoding == "" {
+		return "", ""
+	}
+	values := helper.ParseMultiValuesHeader(acceptEncoding)
+	quality := func(encoding string) float64 {
+		wildcard := 0.0
+		for _, v := range values {
+			if strings.EqualFold(v.Value, encoding) {
+				return v.Priority
+			}
+			if v.Value == "*" {
+				wildcard = v.Priority
+			}
+		}
+		return wildcard
+	}
+
+	encoding, sidecar := "", ""
+	bestQuality := 0.0
+	for _, e := range precompressedEncodings {
+		if q := quality(e.encoding); q > bestQuality && filesystem.FileExistsFS(fsys, file+e.extension) {
+			encoding = e.encoding
+			sidecar = file + e.extension
+			bestQuality = q
+		}
+	}
+	return encoding, sidecar
+}
+
+func listDirectory(response *Response, r *Request, fsys fs.FS, dir string) error {
+	files, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return response.Error(err)
+	}
+
+	base := strings.TrimSuffix(r.URI().Path, "/") + "/"
+	entries := make([]directoryEntry, 0, len(files))
+	for _, f := range files {
+		name := f.Name()
+		if strings.HasPrefix(name, ".") {
+			continue
+		}
+		u := base + url.PathEscape(name)
+		if f.IsDir() {
+			name += "/"
+			u += "/"
+		}
+		entries = append(entries, directoryEntry{Name: name, URL: u})
+	}
+
+	parent := ""
+	if dir != "." {
+		parent = strings.TrimSuffix(path.Dir(strings.TrimSuffix(base, "/")), "/") + "/"
+	}
+
+	response.Header().Set("Content-Type", "text/html; charset=utf-8")
+	response.status = http.StatusOK
+	return directoryListingTemplate.Execute(response, map[string]interface{}{
+		"Path":    base,
+		"Parent":  parent,
+		"Entries": entries,
+	})
+}
+
+// cleanFSPath returns a path valid for "fs.FS" from the requested
+// file, preventing access to the parent directories.
+func cleanFSPath(file string) string {
+	file = strings.TrimPrefix(path.Clean("/"+file), "/")
+	if file == "" {
+		return "."
+	}
+	return file
+}
+
+// cleanStaticPath returns a path valid for "fs.FS" from the
+// requested file, pointing to "index.html" for directories.
+func cleanStaticPath(fsys fs.FS, file string) string {
+	file = cleanFSPath(file)
+	if filesystem.IsDirectoryFS(fsys, file) {
+		file = path.Join(file, "index.html")
+	}
+	return file
+}
@@ -0,0 +1,219 @@
+package goyave
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+type StaticTestSuite struct {
+	TestSuite
+	fsys fstest.MapFS
+}
+
+func (suite *StaticTestSuite) SetupTest() {
+	suite.fsys = fstest.MapFS{
+		"index.html":          {Data: []byte("<html>index</html>")},
+		"app.js":              {Data: []byte("console.log('hello world')")},
+		"app.js.br":           {Data: []byte("brotli")},
+		"app.js.gz":           {Data: []byte("gzip")},
+		"app.3f2a9c1d.js":     {Data: []byte("console.log('fingerprinted')")},
+		"style.css":           {Data: []byte("body{ margin:0; }")},
+		"style.css.gz":        {Data: []byte("gzip")},
+		"docs/readme.txt":     {Data: []byte("readme")},
+		"docs/.hidden":        {Data: []byte("hidden")},
+		"docs/a b.txt":        {Data: []byte("a b")},
+		"docs/guides/1.txt":   {Data: []byte("guide")},
+		"docs/guides/2.txt":   {Data: []byte("guide")},
+		"assets/index.html":   {Data: []byte("<html>assets</html>")},
+		"assets/img/logo.svg": {Data: []byte("<svg></svg>")},
+	}
+}
+
+func (suite *StaticTestSuite) serve(options *StaticOptions, uri string, headers map[string]string) (*http.Response, string) {
+	request, response := createRouterTestRequest(uri)
+	for k, v := range headers {
+		request.httpRequest.Header.Set(k, v)
+	}
+	staticHandler(suite.fsys, options)(response, request)
+	result := response.responseWriter.(*httptest.ResponseRecorder).Result()
+	if !response.wroteHeader {
+		result.StatusCode = response.GetStatus()
+	}
+	body, err := ioutil.ReadAll(result.Body)
+	if err != nil {
+		panic(err)
+	}
+	result.Body.Close()
+	return result, string(body)
+}
+
+func (suite *StaticTestSuite) TestCacheControl() {
+	options := &StaticOptions{
+		Fingerprint:  DefaultFingerprintPattern,
+		CacheControl: "no-cache",
+	}
+	resp, body := suite.serve(options, "/app.3f2a9c1d.js", nil)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal(DefaultFingerprintCacheControl, resp.Header.Get("Cache-Control"))
+	suite.Equal("console.log('fingerprinted')", body)
+
+	resp, _ = suite.serve(options, "/app.js", nil)
+	suite.Equal("no-cache", resp.Header.Get("Cache-Control"))
+
+	options.FingerprintCacheControl = "public, max-age=3600"
+	resp, _ = suite.serve(options, "/app.3f2a9c1d.js", nil)
+	suite.Equal("public, max-age=3600", resp.Header.Get("Cache-Control"))
+
+	resp, _ = suite.serve(options, "/doesn'texist.3f2a9c1d.js", nil)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+	suite.Empty(resp.Header.Get("Cache-Control"))
+
+	resp, _ = suite.serve(&StaticOptions{}, "/app.3f2a9c1d.js", nil)
+	suite.Empty(resp.Header.Get("Cache-Control"))
+
+	suite.True(DefaultFingerprintPattern.MatchString("css/style-8d5e957f.css"))
+	suite.False(DefaultFingerprintPattern.MatchString("app.js"))
+	suite.False(DefaultFingerprintPattern.MatchString("app.min.js"))
+}
+
+func (suite *StaticTestSuite) TestFindPrecompressed() {
+	encoding, file := findPrecompressed(suite.fsys, "app.js", "gzip, deflate, br")
+	suite.Equal("br", encoding)
+	suite.Equal("app.js.br", file)
+
+	encoding, file = findPrecompressed(suite.fsys, "app.js", "br;q=0.5, gzip")
+	suite.Equal("gzip", encoding)
+	suite.Equal("app.js.gz", file)
+
+	encoding, file = findPrecompressed(suite.fsys, "style.css", "br, gzip;q=0.5")
+	suite.Equal("gzip", encoding)
+	suite.Equal("style.css.gz", file)
+
+	encoding, _ = findPrecompressed(suite.fsys, "app.js", "*")
+	suite.Equal("br", encoding)
+
+	encoding, file = findPrecompressed(suite.fsys, "app.js", "")
+	suite.Empty(encoding)
+	suite.Empty(file)
+
+	encoding, file = findPrecompressed(suite.fsys, "app.js", "br; q=0.5, gzip;q=1")
+	suite.Equal("gzip", encoding)
+	suite.Equal("app.js.gz", file)
+
+	encoding, _ = findPrecompressed(suite.fsys, "app.js", "br; q=0, gzip; q=0")
+	suite.Empty(encoding)
+
+	encoding, _ = findPrecompressed(suite.fsys, "app.js", "deflate")
+	suite.Empty(encoding)
+
+	encoding, _ = findPrecompressed(suite.fsys, "index.html", "br, gzip")
+	suite.Empty(encoding)
+}
+
+func (suite *StaticTestSuite) TestPrecompressed() {
+	options := &StaticOptions{Precompressed: true}
+	resp, body := suite.serve(options, "/app.js", map[string]string{"Accept-Encoding": "gzip, br"})
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("br", resp.Header.Get("Content-Encoding"))
+	suite.Equal("text/javascript", resp.Header.Get("Content-Type"))
+	suite.Equal("Accept-Encoding", resp.Header.Get("Vary"))
+	suite.Equal("brotli", body)
+
+	resp, body = suite.serve(options, "/app.js", nil)
+	suite.Empty(resp.Header.Get("Content-Encoding"))
+	suite.Equal("Accept-Encoding", resp.Header.Get("Vary"))
+	suite.Equal("console.log('hello world')", body)
+
+	resp, body = suite.serve(&StaticOptions{}, "/app.js", map[string]string{"Accept-Encoding": "br"})
+	suite.Empty(resp.Header.Get("Content-Encoding"))
+	suite.Empty(resp.Header.Get("Vary"))
+	suite.Equal("console.log('hello world')", body)
+
+	// The download name is the one of the original file
+	resp, _ = suite.serve(&StaticOptions{Precompressed: true, Download: true}, "/style.css", map[string]string{"Accept-Encoding": "gzip"})
+	suite.Equal("gzip", resp.Header.Get("Content-Encoding"))
+	suite.Equal("text/css", resp.Header.Get("Content-Type"))
+	suite.Equal("attachment; filename=\"style.css\"", resp.Header.Get("Content-Disposition"))
+}
+
+func (suite *StaticTestSuite) TestFallback() {
+	options := &StaticOptions{Fallback: "index.html"}
+	resp, body := suite.serve(options, "/users/42", nil)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("<html>index</html>", body)
+
+	resp, body = suite.serve(options, "/app.js", nil)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("console.log('hello world')", body)
+
+	resp, body = suite.serve(options, "/assets", nil)
+	suite.Equal("<html>assets</html>", body)
+
+	resp, _ = suite.serve(&StaticOptions{Fallback: "doesn'texist.html"}, "/users/42", nil)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp, _ = suite.serve(&StaticOptions{}, "/users/42", nil)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *StaticTestSuite) TestDirectoryListing() {
+	options := &StaticOptions{DirectoryListing: true}
+	resp, body := suite.serve(options, "/docs", nil)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+	suite.Contains(body, "<title>Index of /docs/</title>")
+	suite.Contains(body, "<li><a href=\"/\">../</a></li>")
+	suite.Contains(body, "<li><a href=\"/docs/a%20b.txt\">a b.txt</a></li>")
+	suite.Contains(body, "<li><a href=\"/docs/guides/\">guides/</a></li>")
+	suite.Contains(body, "<li><a href=\"/docs/readme.txt\">readme.txt</a></li>")
+	suite.NotContains(body, ".hidden")
+
+	resp, body = suite.serve(options, "/docs/guides/", nil)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Contains(body, "<li><a href=\"/docs/\">../</a></li>")
+	suite.Contains(body, "<li><a href=\"/docs/guides/1.txt\">1.txt</a></li>")
+
+	// Directories having an index are not listed
+	_, body = suite.serve(options, "/assets", nil)
+	suite.Equal("<html>assets</html>", body)
+
+	_, body = suite.serve(options, "/assets/img", nil)
+	suite.Contains(body, "<li><a href=\"/assets/img/logo.svg\">logo.svg</a></li>")
+
+	resp, _ = suite.serve(&StaticOptions{}, "/docs", nil)
+	suite.Equal(http.StatusNotFound, resp.StatusCode)
+}
+
+func (suite *StaticTestSuite) TestStaticWithOptions() {
+	suite.RunServer(func(router *Router) {
+		router.StaticWithOptions("/public", suite.fsys, &StaticOptions{Fallback: "index.html"})
+		router.StaticWithOptions("/nil", suite.fsys, nil)
+	}, func() {
+		resp, err := suite.Get("/public/app.js", nil)
+		suite.Nil(err)
+		if err == nil {
+			suite.Equal("console.log('hello world')", string(suite.GetBody(resp)))
+		}
+
+		resp, err = suite.Get("/public/users/42", nil)
+		suite.Nil(err)
+		if err == nil {
+			suite.Equal(http.StatusOK, resp.StatusCode)
+			suite.Equal("<html>index</html>", string(suite.GetBody(resp)))
+		}
+
+		resp, err = suite.Get("/nil/users/42", nil)
+		suite.Nil(err)
+		if err == nil {
+			resp.Body.Close()
+			suite.Equal(http.StatusNotFound, resp.StatusCode)
+		}
+	})
+}
+
+func TestStaticSuite(t *testing.T) {
+	RunTest(t, new(StaticTestSuite))
+}
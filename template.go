@@ -0,0 +1,356 @@
+package goyave
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+
+	"goyave.dev/goyave/v3/config"
+	"goyave.dev/goyave/v3/lang"
+)
+
+// Templates in these directories of the template filesystem are shared: they are
+// available in every other template, using "{{template "layouts/app.html" .}}" for example.
+var sharedTemplateDirectories = []string{"layouts/", "partials/"}
+
+var (
+	templateFuncs = map[string]interface{}{
+		"route": templateRoute,
+		"trans": lang.Get,
+		"asset": templateAsset,
+	}
+	templateFuncsMutex = &sync.RWMutex{}
+
+	views      *templateEngine
+	viewsFS    fs.FS
+	viewsMutex = &sync.Mutex{}
+)
+
+// SetTemplateFS set the filesystem "Response.Render" and "Response.RenderHTML"
+// read the templates from, instead of the "resources/template" directory.
+// Template paths are relative to the root of this filesystem. Use "fs.Sub"
+// to use a subdirectory.
+//
+//  //go:embed resources/template
+//  var templates embed.FS
+//
+//  sub, _ := fs.Sub(templates, "resources/template")
+//  goyave.SetTemplateFS(sub)
+//
+// Passing nil restores the default behavior.
+func SetTemplateFS(fsys fs.FS) {
+	viewsMutex.Lock()
+	defer viewsMutex.Unlock()
+	viewsFS = fsys
+	views = nil
+}
+
+// RegisterTemplateFunc register a function available in all templates rendered
+// with "Response.Render" and "Response.RenderHTML". Registering a function with
+// the name of an existing one overrides it. The function must follow the
+// requirements of "text/template.FuncMap".
+//
+// Functions must be registered before the templates using them are rendered for
+// the first time, preferably at startup. Templates are parsed again after a registration.
+//
+// The following functions are available by default:
+//  - "route": build the URL of a named route. {{route "user.show" "42"}}
+//  - "trans": get a translated line. {{trans "en-US" "malformed-json"}}
+//  - "asset": build the URL of a static file. {{asset "css/app.css"}}
+func RegisterTemplateFunc(name string, function interface{}) {
+	templateFuncsMutex.Lock()
+	templateFuncs[name] = function
+	templateFuncsMutex.Unlock()
+
+	viewsMutex.Lock()
+	views = nil
+	viewsMutex.Unlock()
+}
+
+func getTemplateFuncs() map[string]interface{} {
+	templateFuncsMutex.RLock()
+	defer templateFuncsMutex.RUnlock()
+	funcs := make(map[string]interface{}, len(templateFuncs))
+	for k, v := range templateFuncs {
+		funcs[k] = v
+	}
+	return funcs
+}
+
+func templateRoute(name string, parameters ...string) (string, error) {
+	route := GetRoute(name)
+	if route == nil {
+		return "", fmt.Errorf("Route %q does not exist", name)
+	}
+	return route.BuildURL(parameters...), nil
+}
+
+func templateAsset(path string) string {
+	return BaseURL() + "/" + strings.TrimPrefix(path, "/")
+}
+
+// getViews returns the template engine for the filesystem set with
+// "SetTemplateFS" or the given template directory, creating it if needed.
+func getViews(directory string) *templateEngine {
+	viewsMutex.Lock()
+	defer viewsMutex.Unlock()
+	if viewsFS != nil {
+		if views == nil {
+			views = newTemplateEngine(viewsFS, getTemplateFuncs())
+		}
+		return views
+	}
+	if views == nil || views.directory != directory {
+		views = newTemplateEngine(os.DirFS(directory), getTemplateFuncs())
+		views.directory = directory
+	}
+	return views
+}
+
+// templateEngine parses a template filesystem once and keeps the
+// result in cache. Templates are parsed separately for "text/template"
+// and "html/template", on first use.
+//
+// Each template is parsed in its own set, along with the shared templates
+// (layouts and partials), so pages can define the same blocks without
+// conflicting with each other.
+type templateEngine struct {
+	mu        sync.RWMutex
+	fsys      fs.FS
+	directory string // Empty if not using the OS filesystem
+	funcs     map[string]interface{}
+	signature string
+
+	text       map[string]templateExecutor
+	textErrors map[string]error
+	html       map[string]templateExecutor
+	htmlErrors map[string]error
+}
+
+type templateFile struct {
+	name    string
+	content string
+}
+
+func newTemplateEngine(fsys fs.FS, funcs map[string]interface{}) *templateEngine {
+	return &templateEngine{
+		fsys:  fsys,
+		funcs: funcs,
+	}
+}
+
+// refresh discards the parsed templates if at least one file
+// in the template filesystem has been created, modified or removed
+// since they were parsed.
+func (e *templateEngine) refresh() {
+	signature, err := e.computeSignature()
+	if err != nil {
+		signature = ""
+	}
+	e.mu.Lock()
+	if signature != e.signature || signature == "" {
+		e.signature = signature
+		e.text = nil
+		e.textErrors = nil
+		e.html = nil
+		e.htmlErrors = nil
+	}
+	e.mu.Unlock()
+}
+
+func (e *templateEngine) computeSignature() (string, error) {
+	var b strings.Builder
+	err := fs.WalkDir(e.fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			b.WriteString(path)
+			b.WriteByte(':')
+			b.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 16))
+			b.WriteByte(':')
+			b.WriteString(strconv.FormatInt(info.Size(), 16))
+			b.WriteByte(';')
+		}
+		return nil
+	})
+	return b.String(), err
+}
+
+// readFiles reads all the templates in the filesystem. Names are
+// the paths of the files, relative to the root of the filesystem.
+func (e *templateEngine) readFiles() ([]*templateFile, error) {
+	files := []*templateFile{}
+	err := fs.WalkDir(e.fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := fs.ReadFile(e.fsys, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, &templateFile{
+			name:    path,
+			content: string(content),
+		})
+		return nil
+	})
+	return files, err
+}
+
+func isSharedTemplate(name string) bool {
+	for _, dir := range sharedTemplateDirectories {
+		if strings.HasPrefix(name, dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateParser parses the given files into a new "text/template"
+// or "html/template" set.
+type templateParser func(files []*templateFile) (templateExecutor, error)
+
+// templateExecutor a parsed "text/template" or "html/template" set.
+type templateExecutor interface {
+	ExecuteTemplate(w io.Writer, name string, data interface{}) error
+}
+
+// loadTemplates reads the template files and parses them using the given
+// parser. Returns the parsed sets and the parsing errors, identified by
+// the names of the templates.
+func (e *templateEngine) loadTemplates(parse templateParser) (map[string]templateExecutor, map[string]error) {
+	templates := map[string]templateExecutor{}
+	errs := map[string]error{}
+	files, err := e.readFiles()
+	if err != nil {
+		errs[""] = err // Directory cannot be read
+		return templates, errs
+	}
+
+	shared := []*templateFile{}
+	pages := []*templateFile{}
+	for _, f := range files {
+		if isSharedTemplate(f.name) {
+			shared = append(shared, f)
+		} else {
+			pages = append(pages, f)
+		}
+	}
+
+	sharedSet, err := parse(shared)
+	if err != nil {
+		// All templates depend on the shared ones
+		for _, f := range files {
+			errs[f.name] = err
+		}
+		return templates, errs
+	}
+	for _, f := range shared {
+		templates[f.name] = sharedSet
+	}
+	for _, f := range pages {
+		set, err := parse(append(shared[:len(shared):len(shared)], f))
+		if err != nil {
+			errs[f.name] = err
+			continue
+		}
+		templates[f.name] = set
+	}
+	return templates, errs
+}
+
+func (e *templateEngine) parseText(files []*templateFile) (templateExecutor, error) {
+	set := template.New("").Funcs(e.funcs)
+	for _, f := range files {
+		if _, err := set.New(f.name).Parse(f.content); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+func (e *templateEngine) parseHTML(files []*templateFile) (templateExecutor, error) {
+	set := htmltemplate.New("").Funcs(e.funcs)
+	for _, f := range files {
+		if _, err := set.New(f.name).Parse(f.content); err != nil {
+			return nil, err
+		}
+	}
+	return set, nil
+}
+
+// executeText executes the template identified by the given name
+// (its path in the template filesystem) using "text/template".
+func (e *templateEngine) executeText(w io.Writer, name string, data interface{}) error {
+	return e.execute(w, name, data, false)
+}
+
+// executeHTML executes the template identified by the given name
+// (its path in the template filesystem) using "html/template".
+func (e *templateEngine) executeHTML(w io.Writer, name string, data interface{}) error {
+	return e.execute(w, name, data, true)
+}
+
+func (e *templateEngine) execute(w io.Writer, name string, data interface{}, html bool) error {
+	e.load(html)
+	name = path.Clean(filepath.ToSlash(name))
+
+	e.mu.RLock()
+	templates, errs := e.text, e.textErrors
+	if html {
+		templates, errs = e.html, e.htmlErrors
+	}
+	tmplt, exists := templates[name]
+	err := errs[name]
+	if err == nil {
+		err = errs[""]
+	}
+	e.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("Template %q does not exist", name)
+	}
+	return tmplt.ExecuteTemplate(w, name, data)
+}
+
+// load parses the templates if they are not in cache yet. If "app.debug"
+// is enabled, the cache is discarded when the template files change.
+func (e *templateEngine) load(html bool) {
+	if config.GetBool("app.debug") {
+		e.refresh()
+	}
+
+	e.mu.RLock()
+	loaded := (html && e.html != nil) || (!html && e.text != nil)
+	e.mu.RUnlock()
+	if loaded {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if html && e.html == nil {
+		e.html, e.htmlErrors = e.loadTemplates(e.parseHTML)
+	} else if !html && e.text == nil {
+		e.text, e.textErrors = e.loadTemplates(e.parseText)
+	}
+}
@@ -0,0 +1,206 @@
+package goyave
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"goyave.dev/goyave/v3/config"
+)
+
+type TemplateTestSuite struct {
+	TestSuite
+}
+
+func (suite *TemplateTestSuite) createTemplates(files map[string]string) string {
+	dir, err := ioutil.TempDir("", "goyave-templates")
+	if err != nil {
+		panic(err)
+	}
+	for name, content := range files {
+		suite.writeTemplate(dir, name, content)
+	}
+	return dir
+}
+
+func (suite *TemplateTestSuite) writeTemplate(dir, name, content string) {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0744); err != nil {
+		panic(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		panic(err)
+	}
+}
+
+func (suite *TemplateTestSuite) TestRenderLayout() {
+	recorder := httptest.NewRecorder()
+	response := suite.CreateTestResponse(recorder)
+
+	data := map[string]interface{}{
+		"Title":   "Admin",
+		"Message": "<b>Hello</b>",
+	}
+	suite.Nil(response.RenderHTML(http.StatusOK, "page.html", data))
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal("<html>\n    <head><title>Admin</title></head>\n    <body>\n        <header>Admin</header>\n        <p>&lt;b&gt;Hello&lt;/b&gt;</p>\n    </body>\n</html>\n", string(body))
+
+	// Partials can be rendered alone
+	recorder = httptest.NewRecorder()
+	response = suite.CreateTestResponse(recorder)
+	suite.Nil(response.Render(http.StatusOK, "partials/header.html", data))
+	resp = recorder.Result()
+	body, err = ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal("<header>Admin</header>", string(body))
+}
+
+func (suite *TemplateTestSuite) TestSetTemplateFS() {
+	SetTemplateFS(fstest.MapFS{
+		"layouts/main.txt": {Data: []byte("<{{block \"content\" .}}{{end}}>")},
+		"index.txt":        {Data: []byte("{{define \"content\"}}{{.}}{{end}}{{template \"layouts/main.txt\" .}}")},
+	})
+	defer SetTemplateFS(nil)
+
+	recorder := httptest.NewRecorder()
+	response := suite.CreateTestResponse(recorder)
+	suite.Nil(response.Render(http.StatusOK, "index.txt", "embedded"))
+	resp := recorder.Result()
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	suite.Nil(err)
+	suite.Equal("<embedded>", string(body))
+
+	response = suite.CreateTestResponse(httptest.NewRecorder())
+	suite.NotNil(response.Render(http.StatusOK, "error.txt", nil))
+
+	SetTemplateFS(nil)
+	response = suite.CreateTestResponse(httptest.NewRecorder())
+	suite.Nil(response.Render(http.StatusOK, "error.txt", nil))
+}
+
+func (suite *TemplateTestSuite) TestTemplateEngine() {
+	dir := suite.createTemplates(map[string]string{
+		"layouts/base.txt": "[{{block \"content\" .}}default{{end}}]",
+		"a.txt":            "{{define \"content\"}}a{{end}}{{template \"layouts/base.txt\" .}}",
+		"sub/b.txt":        "{{define \"content\"}}b{{end}}{{template \"layouts/base.txt\" .}}",
+		"invalid.txt":      "{{.Unclosed",
+	})
+	defer os.RemoveAll(dir)
+	engine := newTemplateEngine(os.DirFS(dir), getTemplateFuncs())
+
+	buf := &bytes.Buffer{}
+	suite.Nil(engine.executeText(buf, "a.txt", nil))
+	suite.Equal("[a]", buf.String())
+
+	buf.Reset()
+	suite.Nil(engine.executeHTML(buf, "sub/b.txt", nil))
+	suite.Equal("[b]", buf.String())
+
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "layouts/base.txt", nil))
+	suite.Equal("[default]", buf.String())
+
+	// Parse errors only affect the invalid template
+	suite.NotNil(engine.executeText(buf, "invalid.txt", nil))
+	suite.NotNil(engine.executeHTML(buf, "invalid.txt", nil))
+	suite.NotNil(engine.executeText(buf, "not-a-template.txt", nil))
+
+	// Invalid shared templates affect all templates
+	suite.writeTemplate(dir, "partials/invalid.txt", "{{end}}")
+	engine = newTemplateEngine(os.DirFS(dir), getTemplateFuncs())
+	suite.NotNil(engine.executeText(buf, "a.txt", nil))
+	suite.NotNil(engine.executeHTML(buf, "a.txt", nil))
+
+	// Directory doesn't exist
+	engine = newTemplateEngine(os.DirFS(filepath.Join(dir, "not-a-directory")), getTemplateFuncs())
+	suite.NotNil(engine.executeText(buf, "a.txt", nil))
+}
+
+func (suite *TemplateTestSuite) TestTemplateHotReload() {
+	dir := suite.createTemplates(map[string]string{"index.txt": "version 1"})
+	defer os.RemoveAll(dir)
+	engine := newTemplateEngine(os.DirFS(dir), getTemplateFuncs())
+	prev := config.Get("app.debug")
+	defer config.Set("app.debug", prev)
+
+	config.Set("app.debug", false)
+	buf := &bytes.Buffer{}
+	suite.Nil(engine.executeText(buf, "index.txt", nil))
+	suite.Equal("version 1", buf.String())
+
+	suite.writeTemplate(dir, "index.txt", "version two")
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "index.txt", nil))
+	suite.Equal("version 1", buf.String())
+
+	config.Set("app.debug", true)
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "index.txt", nil))
+	suite.Equal("version two", buf.String())
+
+	suite.writeTemplate(dir, "other.txt", "other")
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "other.txt", nil))
+	suite.Equal("other", buf.String())
+}
+
+func (suite *TemplateTestSuite) TestTemplateFuncs() {
+	RegisterTemplateFunc("double", func(i int) int { return i * 2 })
+	defer func() {
+		templateFuncsMutex.Lock()
+		delete(templateFuncs, "double")
+		templateFuncsMutex.Unlock()
+	}()
+
+	dir := suite.createTemplates(map[string]string{
+		"double.txt": "{{double 21}}",
+		"route.txt":  "{{route \"test-route\" \"42\"}}",
+		"trans.txt":  "{{trans \"en-US\" \"malformed-json\"}}",
+		"asset.txt":  "{{asset \"/css/app.css\"}}",
+	})
+	defer os.RemoveAll(dir)
+	engine := newTemplateEngine(os.DirFS(dir), getTemplateFuncs())
+
+	buf := &bytes.Buffer{}
+	suite.Nil(engine.executeText(buf, "double.txt", nil))
+	suite.Equal("42", buf.String())
+
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "trans.txt", nil))
+	suite.Equal("Malformed JSON", buf.String())
+
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "asset.txt", nil))
+	suite.Equal(BaseURL()+"/css/app.css", buf.String())
+
+	// Route doesn't exist
+	suite.NotNil(engine.executeText(buf, "route.txt", nil))
+
+	prevRouter := router
+	router = NewRouter()
+	defer func() { router = prevRouter }()
+	router.Get("/test/{id}", func(response *Response, r *Request) {}).Name("test-route")
+	buf.Reset()
+	suite.Nil(engine.executeText(buf, "route.txt", nil))
+	suite.Equal(BaseURL()+"/test/42", buf.String())
+
+	// Wrong parameter count
+	router.Get("/other/{id}/{name}", func(response *Response, r *Request) {}).Name("test-route-params")
+	suite.writeTemplate(dir, "route.txt", "{{route \"test-route-params\" \"42\"}}")
+	engine = newTemplateEngine(os.DirFS(dir), getTemplateFuncs())
+	suite.NotNil(engine.executeText(buf, "route.txt", nil))
+}
+
+func TestTemplateSuite(t *testing.T) {
+	RunTest(t, new(TemplateTestSuite))
+}
@@ -0,0 +1,159 @@
+package goyave
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+
+	"goyave.dev/goyave/v3/lang"
+)
+
+// ErrHandlerTimeout returned by the response writer when a handler
+// tries to write after its route's timeout has been reached.
+var ErrHandlerTimeout = errors.New("Handler timeout: the response has already been sent")
+
+// timeoutWriter guards the underlying http.ResponseWriter so a handler still
+// running after its deadline cannot write to it anymore. The handler uses its
+// own header map, which is copied to the underlying writer's when the header
+// is written, so the timeout response can be written without data race.
+type timeoutWriter struct {
+	mu          sync.Mutex
+	w           http.ResponseWriter
+	header      http.Header
+	timedOut    bool
+	wroteHeader bool
+}
+
+var _ http.Flusher = (*timeoutWriter)(nil)
+var _ http.Hijacker = (*timeoutWriter)(nil)
+
+func newTimeoutWriter(w http.ResponseWriter) *timeoutWriter {
+	header := make(http.Header, len(w.Header()))
+	for k, v := range w.Header() {
+		header[k] = v
+	}
+	return &timeoutWriter{w: w, header: header}
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.writeHeader(status)
+}
+
+func (tw *timeoutWriter) writeHeader(status int) {
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	dst := tw.w.Header()
+	for k, v := range tw.header {
+		dst[k] = v
+	}
+	tw.w.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, ErrHandlerTimeout
+	}
+	tw.writeHeader(http.StatusOK)
+	return tw.w.Write(b)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	if flusher, ok := tw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return nil, nil, ErrHandlerTimeout
+	}
+	hijacker, ok := tw.w.(http.Hijacker)
+	if !ok {
+		return nil, nil, ErrNotHijackable
+	}
+	c, b, err := hijacker.Hijack()
+	if err == nil {
+		// The connection doesn't belong to the server anymore
+		tw.wroteHeader = true
+	}
+	return c, b, err
+}
+
+// timeout prevents any further write from the handler. Returns true if the
+// header was not written yet, meaning a timeout response can be sent.
+func (tw *timeoutWriter) timeout() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.timedOut = true
+	return !tw.wroteHeader
+}
+
+// runWithTimeout executes the handler in a separate goroutine. If the handler
+// doesn't finish before the request context's deadline, the handler's response
+// is discarded and the status handler for "503 Service Unavailable" is executed.
+// The handler keeps running until it returns, so it should stop when the request
+// context is done.
+func (r *Router) runWithTimeout(ctx context.Context, tw *timeoutWriter, handler Handler, response *Response, request *Request) {
+	done := make(chan struct{})
+	panicChan := make(chan interface{}, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicChan <- p
+			}
+		}()
+		handler(response, request)
+		r.finalize(response, request)
+		close(done)
+	}()
+
+	select {
+	case p := <-panicChan:
+		panic(p)
+	case <-done:
+	case <-ctx.Done():
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if tw.timeout() && ctx.Err() == context.DeadlineExceeded {
+			timeoutRequest := &Request{
+				httpRequest: request.httpRequest,
+				route:       request.route,
+				corsOptions: request.corsOptions,
+				Rules:       request.Rules,
+				Params:      request.Params,
+				Extra:       map[string]interface{}{},
+			}
+			if header := timeoutRequest.Header().Get("Accept-Language"); len(header) > 0 {
+				timeoutRequest.Lang = lang.DetectLanguage(header)
+			} else {
+				timeoutRequest.Lang = defaultLanguage
+			}
+			timeoutResponse := newResponse(tw.w, request.httpRequest)
+			timeoutResponse.Status(http.StatusServiceUnavailable)
+			r.finalize(timeoutResponse, timeoutRequest)
+		}
+	}
+}
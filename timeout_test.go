@@ -0,0 +1,167 @@
+package goyave
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type TimeoutTestSuite struct {
+	TestSuite
+}
+
+func (suite *TimeoutTestSuite) serve(router *Router, uri string) *http.Response {
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", uri, nil))
+	return recorder.Result()
+}
+
+func (suite *TimeoutTestSuite) readBody(resp *http.Response) string {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		panic(err)
+	}
+	resp.Body.Close()
+	return string(body)
+}
+
+func (suite *TimeoutTestSuite) TestGetTimeout() {
+	router := NewRouter()
+	route := router.Get("/", func(response *Response, r *Request) {})
+	suite.Equal(time.Duration(0), route.GetTimeout())
+
+	router.Timeout(2 * time.Second)
+	suite.Equal(2*time.Second, route.GetTimeout())
+
+	subrouter := router.Subrouter("/reports")
+	subroute := subrouter.Get("/", func(response *Response, r *Request) {})
+	suite.Equal(2*time.Second, subroute.GetTimeout())
+
+	subrouter.Timeout(time.Minute)
+	suite.Equal(time.Minute, subroute.GetTimeout())
+
+	suite.Same(subroute, subroute.Timeout(5*time.Second))
+	suite.Equal(5*time.Second, subroute.GetTimeout())
+
+	subroute.Timeout(-1)
+	suite.Equal(time.Duration(0), subroute.GetTimeout())
+
+	subrouter.Timeout(-1)
+	suite.Equal(time.Duration(0), subrouter.Get("/other", func(response *Response, r *Request) {}).GetTimeout())
+}
+
+func (suite *TimeoutTestSuite) TestTimeoutNotReached() {
+	router := NewRouter()
+	router.Get("/", func(response *Response, r *Request) {
+		deadline, ok := r.Context().Deadline()
+		suite.True(ok)
+		suite.True(time.Until(deadline) > 0)
+		response.Header().Set("X-Test", "value")
+		response.String(http.StatusCreated, "hello world")
+	}).Timeout(time.Second)
+
+	resp := suite.serve(router, "/")
+	suite.Equal(http.StatusCreated, resp.StatusCode)
+	suite.Equal("value", resp.Header.Get("X-Test"))
+	suite.Equal("hello world", suite.readBody(resp))
+
+	router.Get("/empty", func(response *Response, r *Request) {}).Timeout(time.Second)
+	resp = suite.serve(router, "/empty")
+	suite.Equal(http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+
+	router.Get("/no-timeout", func(response *Response, r *Request) {
+		_, ok := r.Context().Deadline()
+		suite.False(ok)
+	})
+	resp = suite.serve(router, "/no-timeout")
+	suite.Equal(http.StatusNoContent, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (suite *TimeoutTestSuite) TestTimeoutReached() {
+	router := NewRouter()
+	router.Timeout(20 * time.Millisecond)
+	var release chan struct{}
+	done := make(chan error, 1)
+	router.Get("/", func(response *Response, r *Request) {
+		response.Header().Set("X-Test", "value")
+		<-release
+		suite.Equal(context.DeadlineExceeded, r.Context().Err())
+		done <- response.String(http.StatusOK, "too late")
+	})
+
+	release = make(chan struct{})
+	resp := suite.serve(router, "/")
+	close(release)
+	suite.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	suite.Empty(resp.Header.Get("X-Test"))
+	suite.Equal("{\"error\":\"Service Unavailable\"}\n", suite.readBody(resp))
+	suite.Equal(ErrHandlerTimeout, <-done)
+
+	// Custom status handler
+	router.StatusHandler(func(response *Response, r *Request) {
+		response.String(response.GetStatus(), "custom")
+	}, http.StatusServiceUnavailable)
+	release = make(chan struct{})
+	resp = suite.serve(router, "/")
+	close(release)
+	suite.Equal(http.StatusServiceUnavailable, resp.StatusCode)
+	suite.Equal("custom", suite.readBody(resp))
+	<-done
+}
+
+func (suite *TimeoutTestSuite) TestTimeoutStreaming() {
+	router := NewRouter()
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	router.Get("/", func(response *Response, r *Request) {
+		response.String(http.StatusOK, "hello ")
+		response.Flush()
+		<-release
+		done <- response.String(http.StatusOK, "world")
+	}).Timeout(20 * time.Millisecond)
+
+	resp := suite.serve(router, "/")
+	close(release)
+	suite.Equal(http.StatusOK, resp.StatusCode)
+	suite.Equal("hello ", suite.readBody(resp))
+	suite.Equal(ErrHandlerTimeout, <-done)
+}
+
+func (suite *TimeoutTestSuite) TestTimeoutPanic() {
+	router := NewRouter()
+	router.Get("/", func(response *Response, r *Request) {
+		panic(fmt.Errorf("test panic"))
+	}).Timeout(time.Second)
+
+	prev := ErrLogger.Writer()
+	ErrLogger.SetOutput(ioutil.Discard)
+	defer ErrLogger.SetOutput(prev)
+
+	resp := suite.serve(router, "/")
+	suite.Equal(http.StatusInternalServerError, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func (suite *TimeoutTestSuite) TestErrorDeadlineExceeded() {
+	prev := ErrLogger.Writer()
+	ErrLogger.SetOutput(ioutil.Discard)
+	defer ErrLogger.SetOutput(prev)
+
+	response := newResponse(httptest.NewRecorder(), nil)
+	suite.Nil(response.Error(fmt.Errorf("query failed: %w", context.DeadlineExceeded)))
+	suite.Equal(http.StatusGatewayTimeout, response.GetStatus())
+
+	response = newResponse(httptest.NewRecorder(), nil)
+	suite.Nil(response.Error(context.Canceled))
+	suite.Equal(http.StatusInternalServerError, response.GetStatus())
+}
+
+func TestTimeoutSuite(t *testing.T) {
+	RunTest(t, new(TimeoutTestSuite))
+}
@@ -0,0 +1,226 @@
+package goyave
+
+// routeTree is a compressed prefix tree indexing the routes and subrouters
+// of a single Router. Static parts of the URIs are shared between entries
+// and only constrained parameters require a regular expression to be run,
+// so matching doesn't take linear time in the number of routes.
+//
+// The tree doesn't decide which entry wins: a lookup returns every entry
+// matching the path and the Router keeps its precedence rules (subrouters
+// first, then registration order).
+type routeTree struct {
+	root *treeNode
+}
+
+type treeNode struct {
+	// The embedded segment is the part of the URI this node matches.
+	// For static nodes, the value is a compressed fragment of raw text
+	// and may be split when new entries are inserted.
+	pathSegment
+
+	children []*treeNode
+	routes   []*treeLeaf
+	routers  []*treeLeaf
+}
+
+// treeLeaf is a route or subrouter registered in the tree.
+type treeLeaf struct {
+	index  int // Registration order
+	route  *Route
+	router *Router
+}
+
+// treeMatch is a leaf matching the looked up path.
+type treeMatch struct {
+	*treeLeaf
+	length int      // Length of the matched part of the path
+	values []string // Parameter values, in order of appearance
+}
+
+type treeLookup struct {
+	routers []treeMatch
+	routes  []treeMatch
+}
+
+func newRouteTree() *routeTree {
+	return &routeTree{root: &treeNode{}}
+}
+
+func (t *routeTree) insertRoute(route *Route, index int) {
+	t.root.insert(route.segments, &treeLeaf{index: index, route: route})
+}
+
+func (t *routeTree) insertRouter(router *Router, index int) {
+	t.root.insert(router.segments, &treeLeaf{index: index, router: router})
+}
+
+// lookup returns all the subrouters whose prefix matches the given path and
+// all the routes matching the whole path. Both lists are sorted by
+// registration order.
+func (t *routeTree) lookup(path string) treeLookup {
+	result := treeLookup{}
+	t.root.lookup(path, 0, make([]string, 0, 4), &result)
+	return result
+}
+
+func (n *treeNode) insert(segments []*pathSegment, leaf *treeLeaf) {
+	if len(segments) == 0 {
+		if leaf.route != nil {
+			n.routes = append(n.routes, leaf)
+		} else {
+			n.routers = append(n.routers, leaf)
+		}
+		return
+	}
+
+	segment := segments[0]
+	if segment.kind == segmentStatic {
+		n.insertStatic(segment.value, segments[1:], leaf)
+		return
+	}
+
+	for _, child := range n.children {
+		if child.kind == segment.kind && child.value == segment.value {
+			child.insert(segments[1:], leaf)
+			return
+		}
+	}
+	child := &treeNode{pathSegment: *segment}
+	n.children = append(n.children, child)
+	child.insert(segments[1:], leaf)
+}
+
+func (n *treeNode) insertStatic(value string, segments []*pathSegment, leaf *treeLeaf) {
+	for i, child := range n.children {
+		if child.kind != segmentStatic {
+			continue
+		}
+		common := commonPrefixLength(child.value, value)
+		if common == 0 {
+			continue
+		}
+
+		if common < len(child.value) {
+			// Split the child so the common part can be shared
+			split := &treeNode{
+				pathSegment: pathSegment{kind: segmentStatic, value: child.value[:common]},
+				children:    []*treeNode{child},
+			}
+			child.value = child.value[common:]
+			n.children[i] = split
+			child = split
+		}
+
+		if common == len(value) {
+			child.insert(segments, leaf)
+		} else {
+			child.insertStatic(value[common:], segments, leaf)
+		}
+		return
+	}
+
+	child := &treeNode{pathSegment: pathSegment{kind: segmentStatic, value: value}}
+	n.children = append(n.children, child)
+	child.insert(segments, leaf)
+}
+
+func (n *treeNode) lookup(path string, pos int, values []string, result *treeLookup) {
+	for _, leaf := range n.routers {
+		result.routers = insertMatch(result.routers, leaf, pos, values)
+	}
+	if pos == len(path) {
+		for _, leaf := range n.routes {
+			result.routes = insertMatch(result.routes, leaf, pos, values)
+		}
+	}
+
+	for _, child := range n.children {
+		child := child
+		budget := maxRegexCandidates
+		feasible := func(end int) bool { return child.accepts(path, end) }
+		for end := child.first(path, pos); end != -1; end = child.next(path, pos, end, feasible, &budget) {
+			if child.kind == segmentStatic {
+				child.lookup(path, end, values, result)
+			} else {
+				child.lookup(path, end, append(values, path[pos:end]), result)
+			}
+		}
+		if budget == 0 {
+			// Some candidates were not checked, match the
+			// entries of this branch one by one instead
+			child.lookupFallback(path, result)
+		}
+	}
+}
+
+// lookupFallback matches the whole path against each route and subrouter
+// of this branch of the tree. Entries already matched are left untouched.
+func (n *treeNode) lookupFallback(path string, result *treeLookup) {
+	for _, leaf := range n.routers {
+		if length, values, ok := leaf.router.matchFallback(path, true); ok {
+			result.routers = insertMatch(result.routers, leaf, length, values)
+		}
+	}
+	for _, leaf := range n.routes {
+		if _, values, ok := leaf.route.matchFallback(path, false); ok {
+			result.routes = insertMatch(result.routes, leaf, len(path), values)
+		}
+	}
+	for _, child := range n.children {
+		child.lookupFallback(path, result)
+	}
+}
+
+// accepts returns true if a route or a child of this node can match if the
+// segment of this node ends at the given position. Subrouters are ignored
+// because only their longest match is kept (see "insertMatch").
+func (n *treeNode) accepts(path string, end int) bool {
+	if len(n.routes) > 0 && end == len(path) {
+		return true
+	}
+	for _, child := range n.children {
+		if child.canStartAt(path, end) {
+			return true
+		}
+	}
+	return false
+}
+
+// insertMatch adds the leaf to the matches, keeping them sorted by
+// registration order. If the leaf has already been matched, the matches are
+// left untouched: because candidates are explored longest first, the first
+// match found for a leaf is the one a greedy regular expression would
+// have returned.
+func insertMatch(matches []treeMatch, leaf *treeLeaf, length int, values []string) []treeMatch {
+	i := len(matches)
+	for j, m := range matches {
+		if m.treeLeaf == leaf {
+			return matches
+		}
+		if m.index > leaf.index && j < i {
+			i = j
+		}
+	}
+
+	var cpy []string
+	if len(values) > 0 {
+		cpy = make([]string, len(values))
+		copy(cpy, values)
+	}
+	matches = append(matches, treeMatch{})
+	copy(matches[i+1:], matches[i:])
+	matches[i] = treeMatch{treeLeaf: leaf, length: length, values: cpy}
+	return matches
+}
+
+func commonPrefixLength(a, b string) int {
+	max := len(a)
+	if len(b) < max {
+		max = len(b)
+	}
+	i := 0
+	for i < max && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,182 @@
+package goyave
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RouteTreeTestSuite struct {
+	suite.Suite
+	regexCache map[string]*regexp.Regexp
+}
+
+func (suite *RouteTreeTestSuite) SetupTest() {
+	suite.regexCache = make(map[string]*regexp.Regexp, 5)
+}
+
+func (suite *RouteTreeTestSuite) route(uri string) *Route {
+	route := &Route{uri: uri, methods: []string{"GET"}}
+	route.compileParameters(uri, suite.regexCache)
+	return route
+}
+
+func (suite *RouteTreeTestSuite) router(prefix string) *Router {
+	router := &Router{prefix: prefix}
+	router.compileParameters(prefix, suite.regexCache)
+	return router
+}
+
+func (suite *RouteTreeTestSuite) TestInsertStatic() {
+	tree := newRouteTree()
+	tree.insertRoute(suite.route("/product"), 0)
+	tree.insertRoute(suite.route("/products"), 1)
+	tree.insertRoute(suite.route("/profile"), 2)
+	tree.insertRoute(suite.route("/user"), 3)
+
+	suite.Len(tree.root.children, 1)
+	pr := tree.root.children[0]
+	suite.Equal("/", pr.value)
+	suite.Len(pr.children, 2)
+	suite.Equal("pro", pr.children[0].value)
+	suite.Equal("user", pr.children[1].value)
+
+	pro := pr.children[0]
+	suite.Len(pro.children, 2)
+	suite.Equal("duct", pro.children[0].value)
+	suite.Len(pro.children[0].routes, 1)
+	suite.Equal("file", pro.children[1].value)
+	suite.Len(pro.children[1].routes, 1)
+
+	duct := pro.children[0]
+	suite.Len(duct.children, 1)
+	suite.Equal("s", duct.children[0].value)
+	suite.Len(duct.children[0].routes, 1)
+}
+
+func (suite *RouteTreeTestSuite) TestInsertParameters() {
+	tree := newRouteTree()
+	tree.insertRoute(suite.route("/product/{id}"), 0)
+	tree.insertRoute(suite.route("/product/{name}/details"), 1)
+	tree.insertRoute(suite.route("/product/{id:[0-9]+}"), 2)
+	tree.insertRoute(suite.route("/product/{name:[0-9]+}/details"), 3)
+
+	product := tree.root.children[0]
+	suite.Equal("/product/", product.value)
+	suite.Len(product.children, 2)
+	suite.Equal(segmentParam, product.children[0].kind)
+	suite.Len(product.children[0].routes, 1)
+	suite.Len(product.children[0].children, 1)
+	suite.Equal(segmentRegex, product.children[1].kind)
+	suite.Equal("[0-9]+", product.children[1].value)
+	suite.Len(product.children[1].routes, 1)
+	suite.Len(product.children[1].children, 1)
+}
+
+func (suite *RouteTreeTestSuite) TestLookup() {
+	tree := newRouteTree()
+	param := suite.route("/{param}")
+	show := suite.route("/product/{id:[0-9]+}")
+	index := suite.route("/product")
+	details := suite.route("/product/{id:[0-9]+}/{name}")
+	static := suite.route("/static{resource:.*}")
+	root := suite.route("/")
+	tree.insertRoute(param, 0)
+	tree.insertRoute(show, 1)
+	tree.insertRoute(index, 2)
+	tree.insertRoute(details, 3)
+	tree.insertRoute(static, 4)
+	tree.insertRoute(root, 5)
+
+	lookup := tree.lookup("/product")
+	suite.Empty(lookup.routers)
+	suite.Len(lookup.routes, 2)
+	suite.Same(param, lookup.routes[0].route)
+	suite.Equal([]string{"product"}, lookup.routes[0].values)
+	suite.Same(index, lookup.routes[1].route)
+	suite.Empty(lookup.routes[1].values)
+
+	lookup = tree.lookup("/product/42")
+	suite.Len(lookup.routes, 1)
+	suite.Same(show, lookup.routes[0].route)
+	suite.Equal([]string{"42"}, lookup.routes[0].values)
+
+	lookup = tree.lookup("/product/42/screwdriver")
+	suite.Len(lookup.routes, 1)
+	suite.Same(details, lookup.routes[0].route)
+	suite.Equal([]string{"42", "screwdriver"}, lookup.routes[0].values)
+
+	lookup = tree.lookup("/product/test")
+	suite.Empty(lookup.routes)
+
+	lookup = tree.lookup("/static/css/style.css")
+	suite.Len(lookup.routes, 1)
+	suite.Same(static, lookup.routes[0].route)
+	suite.Equal([]string{"/css/style.css"}, lookup.routes[0].values)
+
+	lookup = tree.lookup("/")
+	suite.Len(lookup.routes, 1)
+	suite.Same(root, lookup.routes[0].route)
+
+	lookup = tree.lookup("")
+	suite.Empty(lookup.routes)
+}
+
+func (suite *RouteTreeTestSuite) TestLookupBeyondBudget() {
+	tree := newRouteTree()
+	letters := suite.route("/{a:[a-z]+}{b:[a-z]{20}}")
+	sibling := suite.route("/{a:[a-z]+}-{b}")
+	tree.insertRoute(letters, 0)
+	tree.insertRoute(sibling, 1)
+
+	path := "/" + strings.Repeat("a", 5) + strings.Repeat("b", 20)
+	lookup := tree.lookup(path)
+	suite.Len(lookup.routes, 1)
+	suite.Same(letters, lookup.routes[0].route)
+	suite.Equal([]string{"aaaaa", strings.Repeat("b", 20)}, lookup.routes[0].values)
+
+	lookup = tree.lookup("/" + strings.Repeat("a", 19))
+	suite.Empty(lookup.routes)
+}
+
+func (suite *RouteTreeTestSuite) TestLookupRouters() {
+	tree := newRouteTree()
+	product := suite.router("/product")
+	group := suite.router("")
+	param := suite.router("/{id:[0-9]+}")
+	route := suite.route("/product/{id}")
+	tree.insertRouter(product, 0)
+	tree.insertRouter(group, 1)
+	tree.insertRouter(param, 2)
+	tree.insertRoute(route, 0)
+
+	lookup := tree.lookup("/product/42")
+	suite.Len(lookup.routers, 2)
+	suite.Same(product, lookup.routers[0].router)
+	suite.Equal(8, lookup.routers[0].length)
+	suite.Same(group, lookup.routers[1].router)
+	suite.Equal(0, lookup.routers[1].length)
+	suite.Len(lookup.routes, 1)
+	suite.Equal([]string{"42"}, lookup.routes[0].values)
+
+	lookup = tree.lookup("/42/details")
+	suite.Len(lookup.routers, 2)
+	suite.Same(group, lookup.routers[0].router)
+	suite.Same(param, lookup.routers[1].router)
+	suite.Equal(3, lookup.routers[1].length)
+	suite.Equal([]string{"42"}, lookup.routers[1].values)
+	suite.Empty(lookup.routes)
+}
+
+func (suite *RouteTreeTestSuite) TestCommonPrefixLength() {
+	suite.Equal(0, commonPrefixLength("", "abc"))
+	suite.Equal(0, commonPrefixLength("abc", "def"))
+	suite.Equal(2, commonPrefixLength("abc", "abd"))
+	suite.Equal(3, commonPrefixLength("abc", "abcdef"))
+}
+
+func TestRouteTreeTestSuite(t *testing.T) {
+	suite.Run(t, new(RouteTreeTestSuite))
+}
@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"strconv"
+	"strings"
+
+	"goyave.dev/goyave/v3/helper"
+)
+
+// Conditional rules make a field required or excluded depending on the
+// presence or the value of other fields.
+//
+// When the field under validation is missing, only the conditional "required_*"
+// rules are executed. "exclude_*" rules are executed before any other rule: if the
+// condition is met, the field is removed from the data and is not validated.
+
+func validateRequiredIf(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if !fieldEqualsAny(resolveWildcard(parameters[0], field), parameters[1:], form) {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+func validateRequiredUnless(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if fieldEqualsAny(resolveWildcard(parameters[0], field), parameters[1:], form) {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+func validateRequiredWith(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if countPresent(field, parameters, form) == 0 {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+func validateRequiredWithAll(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if countPresent(field, parameters, form) != len(parameters) {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+func validateRequiredWithout(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if countPresent(field, parameters, form) == len(parameters) {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+func validateRequiredWithoutAll(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	if countPresent(field, parameters, form) != 0 {
+		return true
+	}
+	return validateRequired(field, value, parameters, form)
+}
+
+// Exclusion rules return false if the field should be excluded.
+
+func validateExcludeIf(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	return !fieldEqualsAny(resolveWildcard(parameters[0], field), parameters[1:], form)
+}
+
+func validateExcludeUnless(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	return fieldEqualsAny(resolveWildcard(parameters[0], field), parameters[1:], form)
+}
+
+func validateExcludeWith(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	return countPresent(field, parameters, form) == 0
+}
+
+func validateExcludeWithout(field string, value interface{}, parameters []string, form map[string]interface{}) bool {
+	return countPresent(field, parameters, form) == len(parameters)
+}
+
+// isExcludeRule returns true if the given rule name is one of the
+// conditional exclusion rules.
+func isExcludeRule(name string) bool {
+	switch name {
+	case "exclude_if", "exclude_unless", "exclude_with", "exclude_without":
+		return true
+	}
+	return false
+}
+
+// isConditionalRequiredRule returns true if the given rule name is one
+// of the conditional "required_*" rules.
+func isConditionalRequiredRule(name string) bool {
+	switch name {
+	case "required_if", "required_unless", "required_with", "required_with_all",
+		"required_without", "required_without_all":
+		return true
+	}
+	return false
+}
+
+// countPresent returns the number of the given fields that are present
+// and not empty in the given data.
+func countPresent(field string, names []string, form map[string]interface{}) int {
+	count := 0
+	for _, name := range names {
+		if validateRequired(resolveWildcard(name, field), nil, nil, form) {
+			count++
+		}
+	}
+	return count
+}
+
+// fieldEqualsAny returns true if the field identified by the given name
+// exists and its value is equal to one of the given values.
+func fieldEqualsAny(name string, values []string, form map[string]interface{}) bool {
+	_, other, _, exists := GetFieldFromName(name, form)
+	if !exists {
+		return false
+	}
+	for _, v := range values {
+		if valueEquals(other, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func valueEquals(value interface{}, str string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == str
+	case bool:
+		b, err := strconv.ParseBool(str)
+		return err == nil && b == v
+	}
+
+	if GetFieldType(value) == "numeric" {
+		f1, err1 := helper.ToFloat64(value)
+		f2, err2 := strconv.ParseFloat(str, 64)
+		return err1 == nil && err2 == nil && f1 == f2
+	}
+	return false
+}
+
+// resolveWildcard replaces the wildcards in the given field name with
+// the indexes found in the given concrete field name, so rules can
+// reference fields of the same array element. For example, "items[].type" is
+// resolved to "items.3.type" for the field "items.3.discount".
+// Wildcards that cannot be resolved are kept as is.
+func resolveWildcard(name, field string) string {
+	i := strings.Index(name, "[]")
+	if i == -1 || !strings.HasPrefix(field, name[:i]+".") {
+		return name
+	}
+	rest := field[i+1:]
+	j := strings.Index(rest, ".")
+	if j == -1 {
+		return name
+	}
+	return name[:i] + "." + rest[:j] + resolveWildcard(name[i+2:], rest[j:])
+}
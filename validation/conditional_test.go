@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v3/lang"
+)
+
+func TestValidateRequiredIf(t *testing.T) {
+	form := map[string]interface{}{"type": "card", "count": 2.0, "enabled": true}
+	assert.False(t, validateRequiredIf("number", nil, []string{"type", "card"}, form))
+	assert.False(t, validateRequiredIf("number", nil, []string{"type", "cash", "card"}, form))
+	assert.True(t, validateRequiredIf("number", nil, []string{"type", "cash"}, form))
+	assert.True(t, validateRequiredIf("number", nil, []string{"missing", "card"}, form))
+	assert.False(t, validateRequiredIf("number", nil, []string{"count", "2"}, form))
+	assert.True(t, validateRequiredIf("number", nil, []string{"count", "3"}, form))
+	assert.False(t, validateRequiredIf("number", nil, []string{"enabled", "true"}, form))
+	assert.True(t, validateRequiredIf("number", nil, []string{"enabled", "false"}, form))
+
+	form["number"] = "1234"
+	assert.True(t, validateRequiredIf("number", "1234", []string{"type", "card"}, form))
+	form["number"] = ""
+	assert.False(t, validateRequiredIf("number", "", []string{"type", "card"}, form))
+}
+
+func TestValidateRequiredUnless(t *testing.T) {
+	form := map[string]interface{}{"type": "card"}
+	assert.True(t, validateRequiredUnless("number", nil, []string{"type", "card"}, form))
+	assert.False(t, validateRequiredUnless("number", nil, []string{"type", "cash"}, form))
+	assert.False(t, validateRequiredUnless("number", nil, []string{"missing", "card"}, form))
+}
+
+func TestValidateRequiredWith(t *testing.T) {
+	form := map[string]interface{}{"a": 1, "b": "", "c": nil}
+	assert.False(t, validateRequiredWith("field", nil, []string{"a"}, form))
+	assert.False(t, validateRequiredWith("field", nil, []string{"a", "d"}, form))
+	assert.True(t, validateRequiredWith("field", nil, []string{"b", "d"}, form))
+	assert.False(t, validateRequiredWith("field", nil, []string{"c"}, form))
+
+	assert.False(t, validateRequiredWithAll("field", nil, []string{"a", "c"}, form))
+	assert.True(t, validateRequiredWithAll("field", nil, []string{"a", "d"}, form))
+
+	assert.False(t, validateRequiredWithout("field", nil, []string{"a", "d"}, form))
+	assert.True(t, validateRequiredWithout("field", nil, []string{"a", "c"}, form))
+
+	assert.False(t, validateRequiredWithoutAll("field", nil, []string{"b", "d"}, form))
+	assert.True(t, validateRequiredWithoutAll("field", nil, []string{"a", "d"}, form))
+
+	form["field"] = "value"
+	assert.True(t, validateRequiredWith("field", "value", []string{"a"}, form))
+}
+
+func TestValidateExclude(t *testing.T) {
+	form := map[string]interface{}{"type": "card", "a": 1}
+	assert.False(t, validateExcludeIf("field", nil, []string{"type", "card"}, form))
+	assert.True(t, validateExcludeIf("field", nil, []string{"type", "cash"}, form))
+	assert.True(t, validateExcludeUnless("field", nil, []string{"type", "card"}, form))
+	assert.False(t, validateExcludeUnless("field", nil, []string{"type", "cash"}, form))
+	assert.False(t, validateExcludeWith("field", nil, []string{"a", "b"}, form))
+	assert.True(t, validateExcludeWith("field", nil, []string{"b"}, form))
+	assert.False(t, validateExcludeWithout("field", nil, []string{"a", "b"}, form))
+	assert.True(t, validateExcludeWithout("field", nil, []string{"a"}, form))
+}
+
+func TestResolveWildcard(t *testing.T) {
+	assert.Equal(t, "type", resolveWildcard("type", "items.3.discount"))
+	assert.Equal(t, "items.3.type", resolveWildcard("items[].type", "items.3.discount"))
+	assert.Equal(t, "orders.1.items.2.type", resolveWildcard("orders[].items[].type", "orders.1.items.2.discount"))
+	assert.Equal(t, "orders.1.type", resolveWildcard("orders[].type", "orders.1.items.2.discount"))
+	assert.Equal(t, "other[].type", resolveWildcard("other[].type", "items.3.discount"))
+	assert.Equal(t, "items[].type", resolveWildcard("items[].type", "items"))
+}
+
+func TestValidateConditionalRules(t *testing.T) {
+	lang.LoadDefault()
+	set := RuleSet{
+		"type":        {"required", "string", "in:card,cash"},
+		"card_number": {"required_if:type,card", "digits"},
+		"change":      {"exclude_if:type,card", "numeric"},
+		"email":       {"required_without:phone", "email"},
+		"phone":       {"required_without:email", "string"},
+	}
+
+	data := map[string]interface{}{
+		"type":   "card",
+		"change": "stale",
+		"phone":  "0123",
+	}
+	errors := Validate(data, set, true, "en-US")
+	assert.Equal(t, Errors{"card_number": {"The card_number is required when the type is card."}}, errors)
+	assert.NotContains(t, data, "change")
+
+	data = map[string]interface{}{
+		"type":        "cash",
+		"card_number": "1234",
+		"change":      "12.5",
+	}
+	errors = Validate(data, set, true, "en-US")
+	assert.Equal(t, Errors{
+		"email": {"The email address is required when phone is not present."},
+		"phone": {"The phone is required when email address is not present."},
+	}, errors)
+	assert.Equal(t, 12.5, data["change"])
+
+	data = map[string]interface{}{
+		"type":        "card",
+		"card_number": "abc",
+		"email":       "test@example.org",
+	}
+	errors = Validate(data, set, true, "en-US")
+	assert.Equal(t, Errors{"card_number": {"The card_number must be digits only."}}, errors)
+}
+
+func TestValidateConditionalRulesWildcard(t *testing.T) {
+	lang.LoadDefault()
+	set := RuleSet{
+		"items":            {"required", "array"},
+		"items[].type":     {"required", "string"},
+		"items[].discount": {"required_if:items[].type,promo", "exclude_unless:items[].type,promo", "numeric"},
+	}
+
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"type": "regular", "discount": 10.0},
+			map[string]interface{}{"type": "promo"},
+			map[string]interface{}{"type": "promo", "discount": 5.0},
+		},
+	}
+	errors := Validate(data, set, true, "en-US")
+	assert.Equal(t, Errors{"items.1.discount": {"The discount is required when the items[].type is promo."}}, errors)
+	items := data["items"].([]interface{})
+	assert.NotContains(t, items[0], "discount")
+	assert.Contains(t, items[2], "discount")
+}
+
+func TestConditionalRulesCheck(t *testing.T) {
+	rules := RuleSet{
+		"a": {"required_with:b"},
+		"b": {"exclude_if:c,1"},
+		"c": {"required"},
+	}.AsRules()
+	assert.True(t, rules.Fields["a"].isConditionallyRequired)
+	assert.False(t, rules.Fields["a"].isExcludable)
+	assert.True(t, rules.Fields["b"].isExcludable)
+	assert.False(t, rules.Fields["c"].isConditionallyRequired)
+	assert.False(t, rules.Fields["c"].isExcludable)
+
+	assert.Panics(t, func() {
+		RuleSet{"a": {"array", ">required_if:b,1"}}.AsRules()
+	})
+	assert.Panics(t, func() {
+		RuleSet{"a": {"array", ">exclude_if:b,1"}}.AsRules()
+	})
+	assert.Panics(t, func() {
+		RuleSet{"a": {"required_if:b"}}.AsRules()
+	})
+}
@@ -0,0 +1,30 @@
+package validation
+
+import "context"
+
+// Context is the validation context passed to context-aware rules.
+type Context struct {
+	// Context the context of the request, which can be used for database
+	// queries and cancellation.
+	Context context.Context
+
+	// Request the request being validated. This is a "*goyave.Request",
+	// or nil if the validation is not related to a request.
+	Request interface{}
+
+	// Data the validated data. Rules can modify the validated value
+	// by updating this map.
+	Data map[string]interface{}
+
+	// Value the value of the field under validation.
+	Value interface{}
+
+	// Field the name of the field under validation.
+	Field string
+
+	// Parameters the parameters of the rule.
+	Parameters []string
+
+	// Language the language used for the validation messages.
+	Language string
+}
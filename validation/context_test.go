@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v3/lang"
+)
+
+type contextTestKey struct{}
+
+func TestValidateWithContext(t *testing.T) {
+	lang.LoadDefault()
+	var received []*Context
+	AddRule("context_test_rule", &RuleDefinition{
+		ContextFunction: func(ctx *Context) bool {
+			received = append(received, ctx)
+			return ctx.Context.Value(contextTestKey{}) == ctx.Parameters[0]
+		},
+	})
+
+	request := &struct{ ID int }{ID: 1}
+	ctx := context.WithValue(context.Background(), contextTestKey{}, "valid")
+	data := map[string]interface{}{
+		"field": "value",
+		"array": []interface{}{"a", "b"},
+	}
+	set := RuleSet{
+		"field": {"required", "context_test_rule:valid"},
+		"array": {"array", ">context_test_rule:invalid"},
+	}
+
+	errors, details := ValidateWithContext(ctx, request, data, set, true, "en-US")
+	assert.Equal(t, []string{"array"}, keys(errors))
+	assert.Equal(t, "context_test_rule", details.Get("array.0")[0].Rule)
+
+	if assert.Len(t, received, 2) {
+		// Fields are not validated in a specific order
+		if received[0].Field != "field" {
+			received[0], received[1] = received[1], received[0]
+		}
+		c := received[0]
+		assert.Equal(t, request, c.Request)
+		assert.Equal(t, "field", c.Field)
+		assert.Equal(t, "value", c.Value)
+		assert.Equal(t, []string{"valid"}, c.Parameters)
+		assert.Equal(t, data, c.Data)
+		assert.Equal(t, "en-US", c.Language)
+
+		c = received[1]
+		assert.Equal(t, "a", c.Value)
+		assert.Equal(t, map[string]interface{}{"array": "a"}, c.Data)
+	}
+
+	received = nil
+	errors, _ = ValidateDetails(map[string]interface{}{"field": "value"}, set, true, "en-US")
+	assert.Contains(t, errors, "field")
+	if assert.Len(t, received, 1) {
+		assert.Nil(t, received[0].Request)
+		assert.NotNil(t, received[0].Context)
+	}
+}
+
+func TestAddContextRule(t *testing.T) {
+	assert.Panics(t, func() {
+		AddRule("context_test_both", &RuleDefinition{
+			Function:        func(string, interface{}, []string, map[string]interface{}) bool { return true },
+			ContextFunction: func(*Context) bool { return true },
+		})
+	})
+	assert.Panics(t, func() {
+		AddRule("context_test_type", &RuleDefinition{
+			ContextFunction: func(*Context) bool { return true },
+			IsType:          true,
+		})
+	})
+	assert.NotContains(t, validationRules, "context_test_both")
+	assert.NotContains(t, validationRules, "context_test_type")
+}
+
+func keys(errors Errors) []string {
+	k := make([]string, 0, len(errors))
+	for key := range errors {
+		k = append(k, key)
+	}
+	return k
+}
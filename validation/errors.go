@@ -0,0 +1,123 @@
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError a single validation error, containing the name and
+// the parameters of the rule that didn't pass and the localized message.
+type FieldError struct {
+	Rule    string   `json:"rule,omitempty"`
+	Params  []string `json:"params,omitempty"`
+	Message string   `json:"message"`
+}
+
+// ErrorDetails structured validation errors. Unlike "Errors", the errors are
+// nested following the structure of the validated data: the errors of the
+// fields of an object are in "Fields" and the errors of the elements
+// of an array are in "Elements", identified by their index.
+//
+//  {
+//  	"fields": {
+//  		"items": {
+//  			"elements": {
+//  				"3": {
+//  					"fields": {
+//  						"quantity": {
+//  							"errors": [{"rule": "min", "params": ["1"], "message": "The quantity must be at least 1."}]
+//  						}
+//  					}
+//  				}
+//  			}
+//  		}
+//  	}
+//  }
+type ErrorDetails struct {
+	Errors   []*FieldError            `json:"errors,omitempty"`
+	Fields   map[string]*ErrorDetails `json:"fields,omitempty"`
+	Elements map[int]*ErrorDetails    `json:"elements,omitempty"`
+}
+
+// Empty returns true if there is no error in this structure and
+// its children.
+func (e *ErrorDetails) Empty() bool {
+	return len(e.Errors) == 0 && len(e.Fields) == 0 && len(e.Elements) == 0
+}
+
+// Get returns the errors of the field identified by the given dot-separated path.
+// Array elements are identified by their index ("items.3.quantity").
+// Returns nil if the field doesn't have any error.
+func (e *ErrorDetails) Get(path string) []*FieldError {
+	node := e
+	for _, segment := range strings.Split(path, ".") {
+		next, ok := node.Fields[segment]
+		if !ok {
+			index, err := strconv.Atoi(segment)
+			if err != nil {
+				return nil
+			}
+			if next, ok = node.Elements[index]; !ok {
+				return nil
+			}
+		}
+		node = next
+	}
+	return node.Errors
+}
+
+// add an error to the field identified by the given dot-separated path.
+// The data is used to differentiate array indexes from object keys.
+// The given indexes identify the array element that didn't pass validation
+// for rules with an array dimension.
+func (e *ErrorDetails) add(path string, indexes []int, data map[string]interface{}, fieldError *FieldError) {
+	node := e
+	var value interface{} = data
+	for _, segment := range strings.Split(path, ".") {
+		if obj, ok := value.(map[string]interface{}); ok {
+			value = obj[segment]
+			node = node.field(segment)
+			continue
+		}
+
+		if list := reflect.ValueOf(value); list.Kind() == reflect.Slice {
+			if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < list.Len() {
+				value = list.Index(index).Interface()
+				node = node.element(index)
+				continue
+			}
+		}
+		value = nil
+		node = node.field(segment)
+	}
+
+	for _, index := range indexes {
+		node = node.element(index)
+	}
+	node.Errors = append(node.Errors, fieldError)
+}
+
+func (e *ErrorDetails) field(name string) *ErrorDetails {
+	if e.Fields == nil {
+		e.Fields = make(map[string]*ErrorDetails, 1)
+	}
+	child, ok := e.Fields[name]
+	if !ok {
+		child = &ErrorDetails{}
+		e.Fields[name] = child
+	}
+	return child
+}
+
+func (e *ErrorDetails) element(index int) *ErrorDetails {
+	if e.Elements == nil {
+		e.Elements = make(map[int]*ErrorDetails, 1)
+	}
+	child, ok := e.Elements[index]
+	if !ok {
+		child = &ErrorDetails{}
+		e.Elements[index] = child
+	}
+	return child
+}
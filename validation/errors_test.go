@@ -0,0 +1,83 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v3/lang"
+)
+
+func TestValidateDetails(t *testing.T) {
+	lang.LoadDefault()
+
+	errors, details := ValidateDetails(nil, RuleSet{}, true, "en-US")
+	assert.Equal(t, Errors{"error": {"Malformed JSON"}}, errors)
+	assert.Equal(t, &ErrorDetails{Errors: []*FieldError{{Message: "Malformed JSON"}}}, details)
+
+	data := map[string]interface{}{
+		"name": "John",
+		"user": map[string]interface{}{"email": "not an email"},
+		"tags": []interface{}{"a", "toolong", "b"},
+		"matrix": []interface{}{
+			[]interface{}{1.0, 2.0},
+			[]interface{}{3.0, 12.0},
+		},
+		"items": []interface{}{
+			map[string]interface{}{"quantity": 1.0},
+			map[string]interface{}{"quantity": 0.0},
+		},
+	}
+	set := RuleSet{
+		"name":             {"required", "string"},
+		"user":             {"required", "object"},
+		"user.email":       {"required", "email"},
+		"user.name":        {"required", "string"},
+		"tags":             {"array:string", ">max:3"},
+		"matrix":           {"array", ">array:numeric", ">>max:10"},
+		"items":            {"required", "array"},
+		"items[].quantity": {"required", "numeric", "min:1"},
+	}
+
+	errors, details = ValidateDetails(data, set, true, "en-US")
+	assert.Len(t, errors, 5)
+	assert.False(t, details.Empty())
+	assert.Nil(t, details.Get("name"))
+	assert.Nil(t, details.Get("user"))
+
+	assert.Equal(t, []*FieldError{
+		{Rule: "email", Params: []string{}, Message: "The email address must be a valid email address."},
+	}, details.Get("user.email"))
+	assert.Equal(t, "required", details.Get("user.name")[0].Rule)
+	assert.Equal(t, []*FieldError{
+		{Rule: "max", Params: []string{"3"}, Message: "The tags values may not have more than 3 characters."},
+	}, details.Get("tags.1"))
+	assert.Equal(t, "max", details.Get("matrix.1.1")[0].Rule)
+	assert.Equal(t, []*FieldError{
+		{Rule: "min", Params: []string{"1"}, Message: "The quantity must be at least 1."},
+	}, details.Get("items.1.quantity"))
+	assert.Nil(t, details.Get("items.0.quantity"))
+	assert.Nil(t, details.Get("items.notindex"))
+
+	errors, details = ValidateDetails(map[string]interface{}{"name": "John"}, RuleSet{"name": {"required", "string"}}, true, "en-US")
+	assert.Empty(t, errors)
+	assert.True(t, details.Empty())
+}
+
+func TestErrorDetailsAdd(t *testing.T) {
+	data := map[string]interface{}{
+		"object": map[string]interface{}{
+			"3": "not an index",
+		},
+		"items": []interface{}{"a"},
+	}
+	details := &ErrorDetails{}
+	details.add("object.3", nil, data, &FieldError{Message: "object"})
+	details.add("items.0", nil, data, &FieldError{Message: "element"})
+	details.add("items.4.name", nil, data, &FieldError{Message: "out of range"})
+	details.add("missing.name", []int{1, 2}, data, &FieldError{Message: "indexes"})
+
+	assert.Equal(t, "object", details.Fields["object"].Fields["3"].Errors[0].Message)
+	assert.Equal(t, "element", details.Fields["items"].Elements[0].Errors[0].Message)
+	assert.Equal(t, "out of range", details.Fields["items"].Fields["4"].Fields["name"].Errors[0].Message)
+	assert.Equal(t, "indexes", details.Fields["missing"].Fields["name"].Elements[1].Elements[2].Errors[0].Message)
+}
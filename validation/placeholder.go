@@ -84,6 +84,16 @@ func init() {
 	SetPlaceholder("values", func(field string, rule string, parameters []string, language string) string {
 		return strings.Join(parameters, ", ")
 	})
+	SetPlaceholder("other_values", func(field string, rule string, parameters []string, language string) string {
+		return strings.Join(parameters[1:], ", ")
+	})
+	SetPlaceholder("fields", func(field string, rule string, parameters []string, language string) string {
+		fields := make([]string, 0, len(parameters))
+		for _, p := range parameters {
+			fields = append(fields, replaceField(p, language))
+		}
+		return strings.Join(fields, ", ")
+	})
 	SetPlaceholder("version", func(field string, rule string, parameters []string, language string) string {
 		if len(parameters) > 0 {
 			return "v" + parameters[0]
@@ -0,0 +1,160 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TagName the name of the struct tag used to declare validation rules on
+// struct fields.
+const TagName = "validate"
+
+// FromStruct generates validation rules from the "validate" tags of the fields
+// of the given struct. Rules are separated by a pipe, using the same syntax
+// as RuleSet.
+//
+//  type ArticleRequest struct {
+//  	Title   string   `validate:"required|string|max:255"`
+//  	Tags    []string `validate:"array:string|>max:20"`
+//  	Author  struct {
+//  		Name string `validate:"required|string"`
+//  	} `validate:"required|object"`
+//  }
+//  //...
+//  router.Post("/article", article.Store).Validate(validation.FromStruct(ArticleRequest{}))
+//
+// Field names are converted the same way "Request.ToStruct()" does: the first letter
+// of the field name is lowercased ("Title" becomes "title"). Fields of nested structs
+// are named using the dot-separated syntax ("author.name"), the fields of structs
+// in slices are named using the wildcard syntax ("items[].quantity") and the fields
+// of embedded structs are promoted. Fields without tag or with the "-" tag are ignored.
+//
+// The "array" rule is automatically added to slice fields if it's missing. Its
+// parameter is inferred from the element type when possible
+// ("[]string" becomes "array:string").
+//
+// Panics if the given value is not a struct or a pointer to a struct, if one of the
+// rules is invalid, or if the struct is recursive (e.g. "Children []*Node" in "Node")
+// because the rules of a recursive structure cannot be generated.
+func FromStruct(dto interface{}) *Rules {
+	t := reflect.TypeOf(dto)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("Cannot generate validation rules from non-struct type %v", reflect.TypeOf(dto)))
+	}
+
+	set := RuleSet{}
+	ruleSetFromStruct(set, t, "", map[reflect.Type]bool{})
+	return set.AsRules()
+}
+
+// ruleSetFromStruct adds the rules of the fields of the given struct type to the
+// given set. "visiting" contains the struct types along the current recursion path.
+func ruleSetFromStruct(set RuleSet, t reflect.Type, prefix string, visiting map[reflect.Type]bool) {
+	if visiting[t] {
+		panic(fmt.Sprintf("Cannot generate validation rules from recursive type %v (at %q)", t, strings.TrimSuffix(prefix, ".")))
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" && !field.Anonymous { // Unexported
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		fieldType := indirectType(field.Type)
+
+		if field.Anonymous && !hasTag {
+			if isNestedStruct(fieldType) {
+				ruleSetFromStruct(set, fieldType, prefix, visiting)
+			}
+			continue
+		}
+
+		name := prefix + fieldName(field.Name)
+		if hasTag && tag != "" {
+			rules := strings.Split(tag, "|")
+			if fieldType.Kind() == reflect.Slice && !containsArrayRule(rules) {
+				rules = insertArrayRule(rules, arrayRule(fieldType.Elem()))
+			}
+			set[name] = rules
+		}
+
+		if isNestedStruct(fieldType) {
+			ruleSetFromStruct(set, fieldType, name+".", visiting)
+		} else if fieldType.Kind() == reflect.Slice {
+			if elem := indirectType(fieldType.Elem()); isNestedStruct(elem) {
+				ruleSetFromStruct(set, elem, name+"[].", visiting)
+			}
+		}
+	}
+}
+
+// fieldName converts a struct field name to the corresponding key in the
+// request data, the same way "Request.ToStruct()" does.
+func fieldName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func isNestedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{})
+}
+
+func containsArrayRule(rules []string) bool {
+	for _, rule := range rules {
+		if rule == "array" || strings.HasPrefix(rule, "array:") {
+			return true
+		}
+	}
+	return false
+}
+
+// insertArrayRule inserts the given array rule after the leading
+// "required" and "nullable" rules.
+func insertArrayRule(rules []string, arrayRule string) []string {
+	i := 0
+	for i < len(rules) && (rules[i] == "required" || rules[i] == "nullable") {
+		i++
+	}
+	result := make([]string, 0, len(rules)+1)
+	result = append(result, rules[:i]...)
+	result = append(result, arrayRule)
+	return append(result, rules[i:]...)
+}
+
+// arrayRule returns the "array" rule with the type parameter matching
+// the given element type.
+func arrayRule(elem reflect.Type) string {
+	switch indirectType(elem).Kind() {
+	case reflect.String:
+		return "array:string"
+	case reflect.Bool:
+		return "array:bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "array:integer"
+	case reflect.Float32, reflect.Float64:
+		return "array:numeric"
+	}
+	return "array"
+}
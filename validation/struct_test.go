@@ -0,0 +1,161 @@
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"goyave.dev/goyave/v3/lang"
+)
+
+type structTestAuthor struct {
+	Name  string `validate:"required|string"`
+	Email string `validate:"email"`
+}
+
+type structTestTimestamps struct {
+	CreatedAt time.Time `validate:"date"`
+}
+
+type structTestItem struct {
+	ProductID int `validate:"required|integer"`
+	Quantity  int `validate:"required|integer|min:1"`
+}
+
+type structTestArticle struct {
+	structTestTimestamps
+	Title    string            `validate:"required|string|max:255"`
+	Tags     []string          `validate:">max:20"`
+	Scores   []float64         `validate:"array|>min:0"`
+	IDs      []int             `validate:"required"`
+	Author   *structTestAuthor `validate:"required|object"`
+	Editor   structTestAuthor
+	Items    []*structTestItem `validate:"array"`
+	Ignored  string            `validate:"-"`
+	NoTag    string
+	internal string `validate:"required"`
+}
+
+type structTestNode struct {
+	Name     string            `validate:"required|string"`
+	Children []*structTestNode `validate:"array"`
+}
+
+type structTestLinked struct {
+	Value int `validate:"integer"`
+	Next  *structTestLinked
+}
+
+type structTestTree struct {
+	Left  *structTestNode
+	Right *structTestNode
+}
+
+func TestFromStruct(t *testing.T) {
+	rules := FromStruct(&structTestArticle{})
+
+	names := make([]string, 0, len(rules.Fields))
+	for name := range rules.Fields {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"createdAt", "title", "tags", "scores", "iDs",
+		"author", "author.name", "author.email",
+		"editor.name", "editor.email",
+		"items", "items[].productID", "items[].quantity",
+	}, names)
+
+	title := rules.Fields["title"]
+	assert.Len(t, title.Rules, 3)
+	assert.Equal(t, "required", title.Rules[0].Name)
+	assert.Equal(t, "max", title.Rules[2].Name)
+	assert.Equal(t, []string{"255"}, title.Rules[2].Params)
+
+	tags := rules.Fields["tags"]
+	assert.Len(t, tags.Rules, 2)
+	assert.Equal(t, "array", tags.Rules[0].Name)
+	assert.Equal(t, []string{"string"}, tags.Rules[0].Params)
+	assert.Equal(t, "max", tags.Rules[1].Name)
+	assert.Equal(t, uint8(1), tags.Rules[1].ArrayDimension)
+
+	scores := rules.Fields["scores"]
+	assert.Len(t, scores.Rules, 2)
+	assert.Equal(t, "array", scores.Rules[0].Name)
+	assert.Empty(t, scores.Rules[0].Params)
+
+	ids := rules.Fields["iDs"]
+	assert.Equal(t, "array", ids.Rules[1].Name)
+	assert.Equal(t, []string{"integer"}, ids.Rules[1].Params)
+
+	items := rules.Fields["items"]
+	assert.Len(t, items.Rules, 1)
+	assert.Equal(t, "array", items.Rules[0].Name)
+	assert.True(t, rules.Fields["items[].quantity"].IsRequired())
+
+	assert.True(t, rules.Fields["author"].IsRequired())
+	assert.True(t, rules.Fields["author.name"].IsRequired())
+
+	assert.Panics(t, func() { FromStruct("not a struct") })
+	assert.Panics(t, func() { FromStruct(nil) })
+	assert.Panics(t, func() {
+		FromStruct(struct {
+			Name string `validate:"unknown_rule"`
+		}{})
+	})
+}
+
+func TestFromStructRecursive(t *testing.T) {
+	assert.PanicsWithValue(t, "Cannot generate validation rules from recursive type validation.structTestNode (at \"children[]\")", func() {
+		FromStruct(&structTestNode{})
+	})
+	assert.Panics(t, func() { FromStruct(structTestLinked{}) })
+	assert.Panics(t, func() { FromStruct(structTestTree{}) })
+
+	// The same type in sibling fields is not recursive
+	rules := FromStruct(struct {
+		Author structTestAuthor
+		Editor structTestAuthor
+	}{})
+	assert.True(t, rules.Fields["author.name"].IsRequired())
+	assert.True(t, rules.Fields["editor.name"].IsRequired())
+}
+
+func TestFromStructValidate(t *testing.T) {
+	lang.LoadDefault()
+	rules := FromStruct(structTestArticle{})
+
+	data := map[string]interface{}{
+		"title":  "Hello",
+		"tags":   []string{"a", "b"},
+		"iDs":    []interface{}{1.0, 2.0},
+		"author": map[string]interface{}{"name": "John"},
+		"editor": map[string]interface{}{"name": "Jane"},
+		"items": []interface{}{
+			map[string]interface{}{"productID": 1.0, "quantity": 2.0},
+		},
+	}
+	errors := Validate(data, rules, true, "en-US")
+	assert.Empty(t, errors)
+	assert.Equal(t, []int{1, 2}, data["iDs"])
+
+	data = map[string]interface{}{
+		"title":  "Hello",
+		"iDs":    []interface{}{1.0},
+		"author": map[string]interface{}{},
+	}
+	errors = Validate(data, rules, true, "en-US")
+	assert.Contains(t, errors, "author.name")
+
+	data = map[string]interface{}{
+		"title":  "Hello",
+		"iDs":    []interface{}{1.0},
+		"author": map[string]interface{}{"name": "John"},
+		"editor": map[string]interface{}{"name": "Jane"},
+		"items": []interface{}{
+			map[string]interface{}{"productID": 1.0, "quantity": 2.0},
+			map[string]interface{}{"productID": 2.0, "quantity": 0.0},
+		},
+	}
+	errors = Validate(data, rules, true, "en-US")
+	assert.Equal(t, Errors{"items.1.quantity": {"The quantity must be at least 1."}}, errors)
+}
@@ -1,9 +1,11 @@
 package validation
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 
 	"goyave.dev/goyave/v3/helper"
@@ -24,6 +26,13 @@ type Ruler interface {
 // For example, the "numeric" rule converts the data to float64 if it's a string.
 type RuleFunc func(string, interface{}, []string, map[string]interface{}) bool
 
+// ContextRuleFunc function defining a context-aware validation rule.
+// Passing rules should return true, false otherwise.
+//
+// The given Context gives access to the request being validated and
+// to its "context.Context".
+type ContextRuleFunc func(*Context) bool
+
 // RuleDefinition is the definition of a rule, containing the information
 // related to the behavior executed on validation-time.
 type RuleDefinition struct {
@@ -54,9 +63,19 @@ type RuleDefinition struct {
 	// ComparesFields = true will be executed later in the validation process to
 	// ensure conversions are properly executed prior.
 	ComparesFields bool
+
+	// The ContextFunction field is the function that will be executed
+	// for context-aware rules. Only one of Function and ContextFunction
+	// should be set.
+	ContextFunction ContextRuleFunc
 }
 
 // RuleSet is a request rules definition. Each entry is a field in the request.
+//
+// Nested fields are identified by their dot-separated path ("user.name").
+// Rules can target every object of an array using the wildcard syntax
+// ("items[].quantity"). Errors for such fields are reported using the index of
+// the element ("items.3.quantity").
 type RuleSet map[string][]string
 
 var _ Ruler = (RuleSet)(nil) // implements Ruler
@@ -130,6 +149,9 @@ type Field struct {
 	isArray    bool
 	isRequired bool
 	isNullable bool
+
+	isConditionallyRequired bool
+	isExcludable            bool
 }
 
 // IsRequired check if a field has the "required" rule
@@ -164,6 +186,14 @@ func (f *Field) check() {
 			continue
 		case "array":
 			f.isArray = true
+		default:
+			isConditionallyRequired := isConditionalRequiredRule(rule.Name)
+			isExcludable := isExcludeRule(rule.Name)
+			if (isConditionallyRequired || isExcludable) && rule.ArrayDimension != 0 {
+				panic(fmt.Sprintf("Cannot use rule \"%s\" in array validation", rule.Name))
+			}
+			f.isConditionallyRequired = f.isConditionallyRequired || isConditionallyRequired
+			f.isExcludable = f.isExcludable || isExcludable
 		}
 
 		def, exists := validationRules[rule.Name]
@@ -205,7 +235,10 @@ func (r *Rules) AsRules() *Rules {
 // while ArrayDimension is not equal to 0.
 func (r *Rules) check() {
 	if !r.checked {
-		for _, field := range r.Fields {
+		for name, field := range r.Fields {
+			if isWildcard(name) && !isValidWildcard(name) {
+				panic(fmt.Sprintf("Invalid wildcard field name \"%s\": \"[]\" must be followed by a nested field name", name))
+			}
 			field.check()
 		}
 		r.checked = true
@@ -239,59 +272,69 @@ var validationRules map[string]*RuleDefinition
 
 func init() {
 	validationRules = map[string]*RuleDefinition{
-		"required":           {validateRequired, 0, false, false, false},
-		"numeric":            {validateNumeric, 0, true, false, false},
-		"integer":            {validateInteger, 0, true, false, false},
-		"min":                {validateMin, 1, false, true, false},
-		"max":                {validateMax, 1, false, true, false},
-		"between":            {validateBetween, 2, false, true, false},
-		"greater_than":       {validateGreaterThan, 1, false, true, true},
-		"greater_than_equal": {validateGreaterThanEqual, 1, false, true, true},
-		"lower_than":         {validateLowerThan, 1, false, true, true},
-		"lower_than_equal":   {validateLowerThanEqual, 1, false, true, true},
-		"string":             {validateString, 0, true, false, false},
-		"array":              {validateArray, 0, false, false, false},
-		"distinct":           {validateDistinct, 0, false, false, false},
-		"digits":             {validateDigits, 0, false, false, false},
-		"regex":              {validateRegex, 1, false, false, false},
-		"email":              {validateEmail, 0, false, false, false},
-		"size":               {validateSize, 1, false, true, false},
-		"alpha":              {validateAlpha, 0, false, false, false},
-		"alpha_dash":         {validateAlphaDash, 0, false, false, false},
-		"alpha_num":          {validateAlphaNumeric, 0, false, false, false},
-		"starts_with":        {validateStartsWith, 1, false, false, false},
-		"ends_with":          {validateEndsWith, 1, false, false, false},
-		"in":                 {validateIn, 1, false, false, false},
-		"not_in":             {validateNotIn, 1, false, false, false},
-		"in_array":           {validateInArray, 1, false, false, true},
-		"not_in_array":       {validateNotInArray, 1, false, false, true},
-		"timezone":           {validateTimezone, 0, true, false, false},
-		"ip":                 {validateIP, 0, true, false, false},
-		"ipv4":               {validateIPv4, 0, true, false, false},
-		"ipv6":               {validateIPv6, 0, true, false, false},
-		"json":               {validateJSON, 0, true, false, false},
-		"url":                {validateURL, 0, true, false, false},
-		"uuid":               {validateUUID, 0, true, false, false},
-		"bool":               {validateBool, 0, true, false, false},
-		"same":               {validateSame, 1, false, false, true},
-		"different":          {validateDifferent, 1, false, false, true},
-		"confirmed":          {validateConfirmed, 0, false, false, false},
-		"file":               {validateFile, 0, false, false, false},
-		"mime":               {validateMIME, 1, false, false, false},
-		"image":              {validateImage, 0, false, false, false},
-		"extension":          {validateExtension, 1, false, false, false},
-		"count":              {validateCount, 1, false, false, false},
-		"count_min":          {validateCountMin, 1, false, false, false},
-		"count_max":          {validateCountMax, 1, false, false, false},
-		"count_between":      {validateCountBetween, 2, false, false, false},
-		"date":               {validateDate, 0, true, false, false},
-		"before":             {validateBefore, 1, false, false, true},
-		"before_equal":       {validateBeforeEqual, 1, false, false, true},
-		"after":              {validateAfter, 1, false, false, true},
-		"after_equal":        {validateAfterEqual, 1, false, false, true},
-		"date_equals":        {validateDateEquals, 1, false, false, true},
-		"date_between":       {validateDateBetween, 2, false, false, true},
-		"object":             {validateObject, 0, true, false, false},
+		"required":             {validateRequired, 0, false, false, false, nil},
+		"numeric":              {validateNumeric, 0, true, false, false, nil},
+		"integer":              {validateInteger, 0, true, false, false, nil},
+		"min":                  {validateMin, 1, false, true, false, nil},
+		"max":                  {validateMax, 1, false, true, false, nil},
+		"between":              {validateBetween, 2, false, true, false, nil},
+		"greater_than":         {validateGreaterThan, 1, false, true, true, nil},
+		"greater_than_equal":   {validateGreaterThanEqual, 1, false, true, true, nil},
+		"lower_than":           {validateLowerThan, 1, false, true, true, nil},
+		"lower_than_equal":     {validateLowerThanEqual, 1, false, true, true, nil},
+		"string":               {validateString, 0, true, false, false, nil},
+		"array":                {validateArray, 0, false, false, false, nil},
+		"distinct":             {validateDistinct, 0, false, false, false, nil},
+		"digits":               {validateDigits, 0, false, false, false, nil},
+		"regex":                {validateRegex, 1, false, false, false, nil},
+		"email":                {validateEmail, 0, false, false, false, nil},
+		"size":                 {validateSize, 1, false, true, false, nil},
+		"alpha":                {validateAlpha, 0, false, false, false, nil},
+		"alpha_dash":           {validateAlphaDash, 0, false, false, false, nil},
+		"alpha_num":            {validateAlphaNumeric, 0, false, false, false, nil},
+		"starts_with":          {validateStartsWith, 1, false, false, false, nil},
+		"ends_with":            {validateEndsWith, 1, false, false, false, nil},
+		"in":                   {validateIn, 1, false, false, false, nil},
+		"not_in":               {validateNotIn, 1, false, false, false, nil},
+		"in_array":             {validateInArray, 1, false, false, true, nil},
+		"not_in_array":         {validateNotInArray, 1, false, false, true, nil},
+		"timezone":             {validateTimezone, 0, true, false, false, nil},
+		"ip":                   {validateIP, 0, true, false, false, nil},
+		"ipv4":                 {validateIPv4, 0, true, false, false, nil},
+		"ipv6":                 {validateIPv6, 0, true, false, false, nil},
+		"json":                 {validateJSON, 0, true, false, false, nil},
+		"url":                  {validateURL, 0, true, false, false, nil},
+		"uuid":                 {validateUUID, 0, true, false, false, nil},
+		"bool":                 {validateBool, 0, true, false, false, nil},
+		"same":                 {validateSame, 1, false, false, true, nil},
+		"different":            {validateDifferent, 1, false, false, true, nil},
+		"confirmed":            {validateConfirmed, 0, false, false, false, nil},
+		"file":                 {validateFile, 0, false, false, false, nil},
+		"mime":                 {validateMIME, 1, false, false, false, nil},
+		"image":                {validateImage, 0, false, false, false, nil},
+		"extension":            {validateExtension, 1, false, false, false, nil},
+		"count":                {validateCount, 1, false, false, false, nil},
+		"count_min":            {validateCountMin, 1, false, false, false, nil},
+		"count_max":            {validateCountMax, 1, false, false, false, nil},
+		"count_between":        {validateCountBetween, 2, false, false, false, nil},
+		"date":                 {validateDate, 0, true, false, false, nil},
+		"before":               {validateBefore, 1, false, false, true, nil},
+		"before_equal":         {validateBeforeEqual, 1, false, false, true, nil},
+		"after":                {validateAfter, 1, false, false, true, nil},
+		"after_equal":          {validateAfterEqual, 1, false, false, true, nil},
+		"date_equals":          {validateDateEquals, 1, false, false, true, nil},
+		"date_between":         {validateDateBetween, 2, false, false, true, nil},
+		"object":               {validateObject, 0, true, false, false, nil},
+		"required_if":          {validateRequiredIf, 2, false, false, true, nil},
+		"required_unless":      {validateRequiredUnless, 2, false, false, true, nil},
+		"required_with":        {validateRequiredWith, 1, false, false, true, nil},
+		"required_with_all":    {validateRequiredWithAll, 1, false, false, true, nil},
+		"required_without":     {validateRequiredWithout, 1, false, false, true, nil},
+		"required_without_all": {validateRequiredWithoutAll, 1, false, false, true, nil},
+		"exclude_if":           {validateExcludeIf, 2, false, false, true, nil},
+		"exclude_unless":       {validateExcludeUnless, 2, false, false, true, nil},
+		"exclude_with":         {validateExcludeWith, 1, false, false, true, nil},
+		"exclude_without":      {validateExcludeWithout, 1, false, false, true, nil},
 	}
 }
 
@@ -302,10 +345,25 @@ func init() {
 // Type-dependent messages let you define a different message for
 // numeric, string, arrays and files.
 // The language entry used will be "validation.rules.rulename.type"
+//
+// Context-aware rules are defined using the ContextFunction field instead
+// of the Function field. Type rules cannot be context-aware.
+//  validation.AddRule("owned", &validation.RuleDefinition{
+//  	ContextFunction: func(ctx *validation.Context) bool {
+//  		request := ctx.Request.(*goyave.Request)
+//  		// ...
+//  	},
+//  })
 func AddRule(name string, rule *RuleDefinition) {
 	if _, exists := validationRules[name]; exists {
 		panic(fmt.Sprintf("Rule %s already exists", name))
 	}
+	if rule.Function != nil && rule.ContextFunction != nil {
+		panic(fmt.Sprintf("Rule %s cannot have both Function and ContextFunction", name))
+	}
+	if rule.IsType && rule.ContextFunction != nil {
+		panic(fmt.Sprintf("Type rule %s cannot be context-aware", name))
+	}
 	validationRules[name] = rule
 }
 
@@ -314,6 +372,23 @@ func AddRule(name string, rule *RuleDefinition) {
 // Third parameter tells the function if the data comes from a JSON request.
 // Last parameter sets the language of the validation error messages.
 func Validate(data map[string]interface{}, rules Ruler, isJSON bool, language string) Errors {
+	errors, _ := ValidateDetails(data, rules, isJSON, language)
+	return errors
+}
+
+// ValidateDetails validates the given data with the given rule set, the same
+// way as "Validate()". The structured errors are returned alongside the flat errors.
+// If all validation rules pass, the returned "ErrorDetails" is empty.
+func ValidateDetails(data map[string]interface{}, rules Ruler, isJSON bool, language string) (Errors, *ErrorDetails) {
+	return ValidateWithContext(context.Background(), nil, data, rules, isJSON, language)
+}
+
+// ValidateWithContext validates the given data with the given rule set, the same
+// way as "ValidateDetails()". The given context and request are passed to
+// context-aware rules (see "RuleDefinition.ContextFunction").
+// The request is expected to be a "*goyave.Request", or nil if the
+// validation is not related to a request.
+func ValidateWithContext(ctx context.Context, request interface{}, data map[string]interface{}, rules Ruler, isJSON bool, language string) (Errors, *ErrorDetails) {
 	if data == nil {
 		var malformedMessage string
 		if isJSON {
@@ -321,58 +396,175 @@ func Validate(data map[string]interface{}, rules Ruler, isJSON bool, language st
 		} else {
 			malformedMessage = lang.Get(language, "malformed-request")
 		}
-		return map[string][]string{"error": {malformedMessage}}
+		return map[string][]string{"error": {malformedMessage}}, &ErrorDetails{Errors: []*FieldError{{Message: malformedMessage}}}
 	}
 
-	return validate(data, isJSON, rules.AsRules(), language)
+	v := &validator{
+		ctx:      ctx,
+		request:  request,
+		data:     data,
+		isJSON:   isJSON,
+		language: language,
+		errors:   Errors{},
+		details:  &ErrorDetails{},
+	}
+	v.validate(rules.AsRules())
+	return v.errors, v.details
 }
 
-func validate(data map[string]interface{}, isJSON bool, rules *Rules, language string) Errors {
-	errors := Errors{}
+// validator holds the state of a single validation process.
+type validator struct {
+	ctx      context.Context
+	request  interface{}
+	data     map[string]interface{}
+	isJSON   bool
+	language string
+	errors   Errors
+	details  *ErrorDetails
+}
 
+func (v *validator) validate(rules *Rules) {
 	for _, fieldName := range rules.sortedKeys {
 		field := rules.Fields[fieldName]
-		name, fieldVal, parent, _ := GetFieldFromName(fieldName, data)
-		if !field.IsNullable() && fieldVal == nil {
-			delete(parent, fieldName)
+		if !isWildcard(fieldName) {
+			v.validateField(fieldName, field)
+			continue
 		}
 
-		if !field.IsRequired() && !validateRequired(fieldName, fieldVal, nil, data) {
-			continue
+		for _, name := range expandWildcard(fieldName, v.data) {
+			v.validateField(name, field)
 		}
+	}
+}
 
-		convertArray(isJSON, name, field, parent) // Convert single value arrays in url-encoded requests
+func (v *validator) validateField(fieldName string, field *Field) {
+	name, fieldVal, parent, _ := GetFieldFromName(fieldName, v.data)
+	if field.isExcludable && v.isExcluded(fieldName, field, fieldVal) {
+		delete(parent, name)
+		return
+	}
 
-		for _, rule := range field.Rules {
-			fieldVal = parent[name]
-			if rule.Name == "nullable" {
-				if fieldVal == nil {
-					break
-				}
-				continue
+	if !field.IsNullable() && fieldVal == nil {
+		delete(parent, name)
+	}
+
+	if !field.IsRequired() && !validateRequired(fieldName, fieldVal, nil, v.data) {
+		if field.isConditionallyRequired {
+			v.validateConditionalRequired(fieldName, field)
+		}
+		return
+	}
+
+	convertArray(v.isJSON, name, field, parent) // Convert single value arrays in url-encoded requests
+
+	for _, rule := range field.Rules {
+		fieldVal = parent[name]
+		if rule.Name == "nullable" {
+			if fieldVal == nil {
+				break
 			}
+			continue
+		}
 
-			if rule.ArrayDimension > 0 {
-				if ok, errorValue := validateRuleInArray(rule, fieldName, rule.ArrayDimension, data); !ok {
-					errors[fieldName] = append(
-						errors[fieldName],
-						processPlaceholders(fieldName, rule.Name, rule.Params, getMessage(field.Rules, rule, errorValue, language), language),
-					)
-				}
-			} else if !validationRules[rule.Name].Function(fieldName, fieldVal, rule.Params, data) {
-				errors[fieldName] = append(
-					errors[fieldName],
-					processPlaceholders(fieldName, rule.Name, rule.Params, getMessage(field.Rules, rule, reflect.ValueOf(fieldVal), language), language),
-				)
+		if rule.ArrayDimension > 0 {
+			if ok, errorValue, indexes := v.validateRuleInArray(rule, name, rule.ArrayDimension, parent); !ok {
+				v.addError(fieldName, indexes, rule, getMessage(field.Rules, rule, errorValue, v.language))
 			}
+		} else if !v.call(rule, fieldName, fieldVal, v.data) {
+			v.addError(fieldName, nil, rule, getMessage(field.Rules, rule, reflect.ValueOf(fieldVal), v.language))
 		}
 	}
-	return errors
 }
 
-func validateRuleInArray(rule *Rule, fieldName string, arrayDimension uint8, data map[string]interface{}) (bool, reflect.Value) {
+// call executes the function of the given rule, passing the validation
+// context to context-aware rules.
+func (v *validator) call(rule *Rule, fieldName string, value interface{}, form map[string]interface{}) bool {
+	def := validationRules[rule.Name]
+	if def.ContextFunction != nil {
+		return def.ContextFunction(&Context{
+			Context:    v.ctx,
+			Request:    v.request,
+			Field:      fieldName,
+			Value:      value,
+			Parameters: rule.Params,
+			Data:       form,
+			Language:   v.language,
+		})
+	}
+	return def.Function(fieldName, value, rule.Params, form)
+}
+
+// isExcluded returns true if the condition of one of the exclusion rules
+// of the given field is met.
+func (v *validator) isExcluded(fieldName string, field *Field, value interface{}) bool {
+	for _, rule := range field.Rules {
+		if isExcludeRule(rule.Name) && !v.call(rule, fieldName, value, v.data) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateConditionalRequired executes the conditional "required_*" rules
+// of a missing field. Only the first failing rule generates an error.
+func (v *validator) validateConditionalRequired(fieldName string, field *Field) {
+	for _, rule := range field.Rules {
+		if isConditionalRequiredRule(rule.Name) && !v.call(rule, fieldName, nil, v.data) {
+			v.addError(fieldName, nil, rule, getMessage(field.Rules, rule, reflect.ValueOf(nil), v.language))
+			return
+		}
+	}
+}
+
+func (v *validator) addError(fieldName string, indexes []int, rule *Rule, message string) {
+	message = processPlaceholders(fieldName, rule.Name, rule.Params, message, v.language)
+	v.errors[fieldName] = append(v.errors[fieldName], message)
+	v.details.add(fieldName, indexes, v.data, &FieldError{Rule: rule.Name, Params: rule.Params, Message: message})
+}
+
+// isWildcard returns true if the given field name targets
+// the elements of an array of objects ("items[].quantity").
+func isWildcard(fieldName string) bool {
+	return strings.Contains(fieldName, "[]")
+}
+
+// isValidWildcard returns true if every wildcard in the given field name
+// follows an array name and is followed by a nested field name.
+func isValidWildcard(fieldName string) bool {
+	for _, segment := range strings.Split(fieldName, "[]")[1:] {
+		if len(segment) < 2 || segment[0] != '.' || segment[1] == '.' || segment[1] == '[' {
+			return false
+		}
+	}
+	return !strings.HasPrefix(fieldName, "[]") && !strings.Contains(fieldName, ".[]")
+}
+
+// expandWildcard replaces the wildcards in the given field name with the
+// indexes of the elements found in the given data. For example, "items[].quantity"
+// is expanded to "items.0.quantity", "items.1.quantity", etc.
+// Returns an empty slice if the array doesn't exist or is not an array.
+func expandWildcard(fieldName string, data map[string]interface{}) []string {
+	i := strings.Index(fieldName, "[]")
+	if i == -1 {
+		return []string{fieldName}
+	}
+
+	_, value, _, exists := GetFieldFromName(fieldName[:i], data)
+	list := reflect.ValueOf(value)
+	if !exists || list.Kind() != reflect.Slice || GetFieldType(value) != "array" {
+		return []string{}
+	}
+
+	names := make([]string, 0, list.Len())
+	for j := 0; j < list.Len(); j++ {
+		names = append(names, expandWildcard(fieldName[:i]+"."+strconv.Itoa(j)+fieldName[i+2:], data)...)
+	}
+	return names
+}
+
+func (v *validator) validateRuleInArray(rule *Rule, fieldName string, arrayDimension uint8, data map[string]interface{}) (bool, reflect.Value, []int) {
 	if t := GetFieldType(data[fieldName]); t != "array" {
-		return false, reflect.ValueOf(data[fieldName])
+		return false, reflect.ValueOf(data[fieldName]), nil
 	}
 
 	converted := false
@@ -380,16 +572,19 @@ func validateRuleInArray(rule *Rule, fieldName string, arrayDimension uint8, dat
 	list := reflect.ValueOf(data[fieldName])
 	length := list.Len()
 	for i := 0; i < length; i++ {
-		v := list.Index(i)
-		value := v.Interface()
+		elem := list.Index(i)
+		value := elem.Interface()
 		tmpData := map[string]interface{}{fieldName: value}
 		if arrayDimension > 1 {
-			ok, errorValue := validateRuleInArray(rule, fieldName, arrayDimension-1, tmpData)
+			ok, errorValue, indexes := v.validateRuleInArray(rule, fieldName, arrayDimension-1, tmpData)
 			if !ok {
-				return false, errorValue
+				if indexes == nil { // Element is not an array
+					return false, errorValue, []int{i}
+				}
+				return false, errorValue, append([]int{i}, indexes...)
 			}
-		} else if !validationRules[rule.Name].Function(fieldName, value, rule.Params, tmpData) {
-			return false, v
+		} else if !v.call(rule, fieldName, value, tmpData) {
+			return false, elem, []int{i}
 		}
 
 		// Update original array if value has been modified.
@@ -400,14 +595,14 @@ func validateRuleInArray(rule *Rule, fieldName string, arrayDimension uint8, dat
 			}
 			convertedArr = reflect.Append(convertedArr, reflect.ValueOf(tmpData[fieldName]))
 		} else {
-			v.Set(reflect.ValueOf(tmpData[fieldName]))
+			elem.Set(reflect.ValueOf(tmpData[fieldName]))
 		}
 	}
 
 	if converted {
 		data[fieldName] = convertedArr.Interface()
 	}
-	return true, reflect.Value{}
+	return true, reflect.Value{}, nil
 }
 
 func convertArray(isJSON bool, fieldName string, field *Field, data map[string]interface{}) {
@@ -492,7 +687,8 @@ func getFieldType(value reflect.Value) string {
 }
 
 // GetFieldFromName find potentially nested field by it's dot-separated path
-// in the given object.
+// in the given object. Elements of arrays of objects can be accessed using
+// their index ("items.3.quantity").
 // Returns the name without its prefix, the value, its parent object and a bool indicating if it has been found or not.
 func GetFieldFromName(name string, data map[string]interface{}) (string, interface{}, map[string]interface{}, bool) {
 	key := name
@@ -509,11 +705,31 @@ func GetFieldFromName(name string, data map[string]interface{}) (string, interfa
 		if obj, ok := val.(map[string]interface{}); ok {
 			return GetFieldFromName(name[len(key)+1:], obj)
 		}
+		if list := reflect.ValueOf(val); list.Kind() == reflect.Slice {
+			return getFieldFromArray(name[len(key)+1:], list)
+		}
 	}
 
 	return name, val, data, ok
 }
 
+// getFieldFromArray find a field in the object at the index identified
+// by the first segment of the given path.
+func getFieldFromArray(name string, list reflect.Value) (string, interface{}, map[string]interface{}, bool) {
+	i := strings.Index(name, ".")
+	if i == -1 {
+		return "", nil, nil, false
+	}
+	index, err := strconv.Atoi(name[:i])
+	if err != nil || index < 0 || index >= list.Len() {
+		return "", nil, nil, false
+	}
+	if obj, ok := list.Index(index).Interface().(map[string]interface{}); ok {
+		return GetFieldFromName(name[i+1:], obj)
+	}
+	return "", nil, nil, false
+}
+
 func parseRule(rule string) *Rule {
 	indexName := strings.Index(rule, ":")
 	params := []string{}
@@ -287,7 +287,7 @@ func (suite *ValidatorTestSuite) TestValidateArrayValues() {
 
 	// Cannot validate array values on non-array field string of type string
 	rule := &Rule{Name: "required", ArrayDimension: 1}
-	suite.False(validateRuleInArray(rule, "string", rule.ArrayDimension, map[string]interface{}{"string": "hi"}))
+	suite.False((&validator{}).validateRuleInArray(rule, "string", rule.ArrayDimension, map[string]interface{}{"string": "hi"}))
 
 	// Empty array
 	data = map[string]interface{}{
@@ -794,6 +794,105 @@ func (suite *ValidatorTestSuite) TestGetFieldFromName() {
 	suite.False(ok)
 }
 
+func (suite *ValidatorTestSuite) TestGetFieldFromNameArray() {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"quantity": 2},
+			"notobject",
+		},
+	}
+
+	name, val, parent, ok := GetFieldFromName("items.0.quantity", data)
+	suite.Equal("quantity", name)
+	suite.Equal(2, val)
+	suite.Equal(data["items"].([]interface{})[0], parent)
+	suite.True(ok)
+
+	for _, path := range []string{"items.0", "items.1.quantity", "items.2.quantity", "items.-1.quantity", "items.a.quantity", "items.0.notafield"} {
+		name, val, parent, ok = GetFieldFromName(path, data)
+		suite.Empty(name, path)
+		suite.Nil(val, path)
+		suite.Nil(parent, path)
+		suite.False(ok, path)
+	}
+}
+
+func (suite *ValidatorTestSuite) TestExpandWildcard() {
+	data := map[string]interface{}{
+		"orders": []interface{}{
+			map[string]interface{}{
+				"items": []interface{}{map[string]interface{}{}, map[string]interface{}{}},
+			},
+			map[string]interface{}{
+				"items": []interface{}{map[string]interface{}{}},
+			},
+			map[string]interface{}{},
+		},
+		"notarray": "test",
+	}
+
+	suite.Equal([]string{"orders.0.id", "orders.1.id", "orders.2.id"}, expandWildcard("orders[].id", data))
+	suite.Equal([]string{"orders.0.items.0.id", "orders.0.items.1.id", "orders.1.items.0.id"}, expandWildcard("orders[].items[].id", data))
+	suite.Equal([]string{}, expandWildcard("notarray[].id", data))
+	suite.Equal([]string{}, expandWildcard("missing[].id", data))
+}
+
+func (suite *ValidatorTestSuite) TestValidateWildcard() {
+	set := RuleSet{
+		"items":              {"required", "array"},
+		"items[].product_id": {"required", "integer"},
+		"items[].quantity":   {"required", "integer", "min:1"},
+		"items[].tags":       {"array:string", ">max:5"},
+		"items[].note":       {"string"},
+	}
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"product_id": 1.0, "quantity": 2.0, "tags": []interface{}{"a", "b"}},
+			map[string]interface{}{"product_id": "2", "quantity": 1.0, "note": nil},
+		},
+	}
+	errors := Validate(data, set, true, "en-US")
+	suite.Empty(errors)
+	items := data["items"].([]interface{})
+	suite.Equal(1, items[0].(map[string]interface{})["product_id"])
+	suite.Equal(2, items[0].(map[string]interface{})["quantity"])
+	suite.Equal([]string{"a", "b"}, items[0].(map[string]interface{})["tags"])
+	suite.Equal(2, items[1].(map[string]interface{})["product_id"])
+	suite.NotContains(items[1], "note")
+
+	data = map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"product_id": 1.0, "quantity": 2.0},
+			map[string]interface{}{"product_id": 2.0},
+			map[string]interface{}{"product_id": 3.0, "quantity": 0.0, "tags": []interface{}{"toolong"}},
+			"notobject",
+		},
+	}
+	errors = Validate(data, set, true, "en-US")
+	suite.Equal(Errors{
+		"items.1.quantity":   {"The quantity is required.", "The quantity must be an integer."},
+		"items.2.quantity":   {"The quantity must be at least 1."},
+		"items.2.tags":       {"The tags values may not have more than 5 characters."},
+		"items.3.product_id": {"The product_id is required.", "The product_id must be an integer."},
+		"items.3.quantity":   {"The quantity is required.", "The quantity must be an integer."},
+	}, errors)
+
+	// Missing array: the wildcard fields are not validated
+	errors = Validate(map[string]interface{}{}, set, true, "en-US")
+	suite.Equal(Errors{"items": {"The items is required.", "The items must be an array."}}, errors)
+}
+
+func (suite *ValidatorTestSuite) TestCheckWildcard() {
+	for _, name := range []string{"items[]", "[].id", "items.[].id", "items[][].id", "items[]id", "items[]..id"} {
+		suite.Panics(func() {
+			RuleSet{name: {"required"}}.AsRules()
+		}, name)
+	}
+	suite.NotPanics(func() {
+		RuleSet{"orders[].items[].id": {"required"}}.AsRules()
+	})
+}
+
 func (suite *ValidatorTestSuite) TestTypeDependentAfterConversion() {
 	// Before this bug was fixed, type-dependent rules received the original value
 	// instead of the converted one, leading to wrong validation.